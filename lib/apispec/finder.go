@@ -0,0 +1,92 @@
+package apispec
+
+import (
+	"bloodhound/lib/client"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Locations specs are usually served at, relative to the root of a host
+var WellKnownPaths = []string{
+	"/swagger.json",
+	"/swagger.yaml",
+	"/swagger/v1/swagger.json",
+	"/openapi.json",
+	"/openapi.yaml",
+	"/api-docs",
+	"/v2/api-docs",
+	"/v3/api-docs",
+	"/api/swagger.json",
+	"/api/openapi.json",
+	"/.well-known/openapi.json",
+	"/.well-known/openapi.yaml",
+}
+
+type Finder struct {
+	client  *client.BloodhoundClient
+	limiter *client.RateLimiter
+}
+
+func NewFinder(client *client.BloodhoundClient, limiter *client.RateLimiter) *Finder {
+	return &Finder{
+		client:  client,
+		limiter: limiter,
+	}
+}
+
+// Requests every well-known location of the origin (scheme and host), until one of them serves a spec.
+// Returns nil when no spec is found
+func (finder *Finder) Find(origin string) *Spec {
+	origin = strings.TrimSuffix(origin, "/")
+
+	for _, path := range WellKnownPaths {
+		body, err := finder.fetch(origin + path)
+
+		if err != nil {
+			continue
+		}
+
+		spec, err := Parse(origin+path, body)
+
+		if err != nil {
+			log.WithFields(log.Fields{
+				"target": origin + path,
+				"err":    err.Error(),
+			}).Trace("Response is not an API spec")
+
+			continue
+		}
+
+		return spec
+	}
+
+	return nil
+}
+
+func (finder *Finder) fetch(target string) ([]byte, error) {
+	finder.limiter.Wait()
+
+	request, err := http.NewRequest("GET", target, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := finder.client.Do(request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to find spec, status %d", response.StatusCode)
+	}
+
+	return io.ReadAll(response.Body)
+}
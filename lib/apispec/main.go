@@ -0,0 +1,221 @@
+package apispec
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Value used for path parameters (e.g. /users/{id}), so endpoints can be requested
+const pathParameterValue = "1"
+
+var pathParameter = regexp.MustCompile(`\{[^{}/]+\}`)
+
+// OpenAPI 3 or Swagger 2 document
+type Spec struct {
+	Url       string     `json:"url"`
+	Version   string     `json:"version"`
+	Title     string     `json:"title,omitempty"`
+	Endpoints []Endpoint `json:"endpoints"`
+}
+
+type Endpoint struct {
+	// Requestable URL of the endpoint, path parameters are replaced by a placeholder value
+	Url  string `json:"url"`
+	Path string `json:"path"`
+
+	Methods    []string    `json:"methods"`
+	Parameters []Parameter `json:"parameters,omitempty"`
+}
+
+type Parameter struct {
+	Name string `json:"name" yaml:"name"`
+
+	// Where the parameter is sent: query, path, header, cookie, body or formData
+	In       string `json:"in" yaml:"in"`
+	Required bool   `json:"required,omitempty" yaml:"required"`
+}
+
+type document struct {
+	Swagger string `yaml:"swagger"`
+	Openapi string `yaml:"openapi"`
+	Info    struct {
+		Title string `yaml:"title"`
+	} `yaml:"info"`
+
+	// Swagger 2 location of the API
+	Host     string   `yaml:"host"`
+	BasePath string   `yaml:"basePath"`
+	Schemes  []string `yaml:"schemes"`
+
+	// OpenAPI 3 location of the API
+	Servers []struct {
+		Url string `yaml:"url"`
+	} `yaml:"servers"`
+
+	Paths map[string]pathItem `yaml:"paths"`
+}
+
+type pathItem struct {
+	Get     *operation `yaml:"get"`
+	Put     *operation `yaml:"put"`
+	Post    *operation `yaml:"post"`
+	Delete  *operation `yaml:"delete"`
+	Options *operation `yaml:"options"`
+	Head    *operation `yaml:"head"`
+	Patch   *operation `yaml:"patch"`
+	Trace   *operation `yaml:"trace"`
+
+	// Shared by every operation of the path
+	Parameters []Parameter `yaml:"parameters"`
+}
+
+type operation struct {
+	Parameters  []Parameter `yaml:"parameters"`
+	RequestBody *struct{}   `yaml:"requestBody"`
+}
+
+// Parses a JSON or YAML document, the spec URL is used to resolve where the API is served
+func Parse(specUrl string, body []byte) (*Spec, error) {
+	var doc document
+
+	// JSON documents are valid YAML documents
+	if err := yaml.Unmarshal(body, &doc); err != nil {
+		return nil, err
+	}
+
+	version := doc.Openapi
+	if version == "" {
+		version = doc.Swagger
+	}
+
+	if version == "" || len(doc.Paths) == 0 {
+		return nil, errors.New("document is not an OpenAPI or Swagger spec")
+	}
+
+	base, err := doc.baseUrl(specUrl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &Spec{
+		Url:     specUrl,
+		Version: version,
+		Title:   doc.Info.Title,
+	}
+
+	for path, item := range doc.Paths {
+		if !strings.HasPrefix(path, "/") {
+			continue
+		}
+
+		endpoint := Endpoint{
+			Url:  strings.TrimSuffix(base, "/") + pathParameter.ReplaceAllString(path, pathParameterValue),
+			Path: path,
+		}
+
+		seen := make(map[string]bool)
+		addParameters := func(parameters []Parameter) {
+			for _, parameter := range parameters {
+				// Referenced parameters ($ref) aren't resolved
+				if parameter.Name == "" || seen[parameter.In+":"+parameter.Name] {
+					continue
+				}
+
+				seen[parameter.In+":"+parameter.Name] = true
+				endpoint.Parameters = append(endpoint.Parameters, parameter)
+			}
+		}
+
+		addParameters(item.Parameters)
+
+		for _, method := range item.operations() {
+			endpoint.Methods = append(endpoint.Methods, method.name)
+			addParameters(method.Parameters)
+
+			// OpenAPI 3 describes bodies apart from parameters
+			if method.RequestBody != nil {
+				addParameters([]Parameter{{Name: "requestBody", In: "body"}})
+			}
+		}
+
+		if len(endpoint.Methods) != 0 {
+			spec.Endpoints = append(spec.Endpoints, endpoint)
+		}
+	}
+
+	slices.SortFunc(spec.Endpoints, func(a, b Endpoint) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return spec, nil
+}
+
+func (doc *document) baseUrl(specUrl string) (string, error) {
+	location, err := url.Parse(specUrl)
+
+	if err != nil {
+		return "", err
+	}
+
+	origin := &url.URL{Scheme: location.Scheme, Host: location.Host}
+
+	if doc.Swagger != "" {
+		if doc.Host != "" {
+			origin.Host = doc.Host
+		}
+
+		if len(doc.Schemes) != 0 && !slices.Contains(doc.Schemes, location.Scheme) {
+			origin.Scheme = doc.Schemes[0]
+		}
+
+		origin.Path = doc.BasePath
+
+		return origin.String(), nil
+	}
+
+	// Servers with variables (e.g. https://{region}.example.com) can't be requested as they are
+	if len(doc.Servers) != 0 && !strings.Contains(doc.Servers[0].Url, "{") {
+		server, err := location.Parse(doc.Servers[0].Url)
+
+		if err == nil {
+			server.RawQuery = ""
+			server.Fragment = ""
+
+			return server.String(), nil
+		}
+	}
+
+	return origin.String(), nil
+}
+
+type namedOperation struct {
+	*operation
+	name string
+}
+
+func (item *pathItem) operations() []namedOperation {
+	var operations []namedOperation
+
+	for _, method := range []namedOperation{
+		{item.Get, "GET"},
+		{item.Put, "PUT"},
+		{item.Post, "POST"},
+		{item.Delete, "DELETE"},
+		{item.Options, "OPTIONS"},
+		{item.Head, "HEAD"},
+		{item.Patch, "PATCH"},
+		{item.Trace, "TRACE"},
+	} {
+		if method.operation != nil {
+			operations = append(operations, method)
+		}
+	}
+
+	return operations
+}
@@ -0,0 +1,148 @@
+package apispec
+
+import (
+	"bloodhound/lib/client"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+const swaggerSpec = `{
+	"swagger": "2.0",
+	"info": {"title": "Shop API"},
+	"host": "api.example.com",
+	"basePath": "/v1",
+	"schemes": ["https"],
+	"paths": {
+		"/users/{id}": {
+			"parameters": [{"name": "id", "in": "path", "required": true}],
+			"get": {"parameters": [{"name": "fields", "in": "query"}]},
+			"delete": {}
+		},
+		"/products": {
+			"get": {"parameters": [{"name": "q", "in": "query"}, {"$ref": "#/parameters/Page"}]},
+			"post": {"parameters": [{"name": "body", "in": "body", "required": true}]}
+		}
+	}
+}`
+
+const openapiSpec = `
+openapi: 3.0.1
+info:
+  title: Admin API
+servers:
+  - url: /internal/api
+paths:
+  /reports/{year}:
+    get:
+      parameters:
+        - name: year
+          in: path
+          required: true
+  /export:
+    post:
+      requestBody:
+        content:
+          application/json: {}
+`
+
+func TestParse(t *testing.T) {
+	t.Run("swagger 2", func(t *testing.T) {
+		spec, err := Parse("http://example.com/swagger.json", []byte(swaggerSpec))
+
+		if err != nil {
+			t.Fatalf("Parse; want no error; got %s", err.Error())
+		}
+
+		expected := &Spec{
+			Url:     "http://example.com/swagger.json",
+			Version: "2.0",
+			Title:   "Shop API",
+			Endpoints: []Endpoint{
+				{
+					Url:        "https://api.example.com/v1/products",
+					Path:       "/products",
+					Methods:    []string{"GET", "POST"},
+					Parameters: []Parameter{{Name: "q", In: "query"}, {Name: "body", In: "body", Required: true}},
+				},
+				{
+					Url:        "https://api.example.com/v1/users/1",
+					Path:       "/users/{id}",
+					Methods:    []string{"GET", "DELETE"},
+					Parameters: []Parameter{{Name: "id", In: "path", Required: true}, {Name: "fields", In: "query"}},
+				},
+			},
+		}
+
+		if !reflect.DeepEqual(expected, spec) {
+			t.Errorf("Parse; want %+v; got %+v", expected, spec)
+		}
+	})
+
+	t.Run("openapi 3 with relative server", func(t *testing.T) {
+		spec, err := Parse("http://example.com/openapi.yaml", []byte(openapiSpec))
+
+		if err != nil {
+			t.Fatalf("Parse; want no error; got %s", err.Error())
+		}
+
+		expected := []Endpoint{
+			{
+				Url:        "http://example.com/internal/api/export",
+				Path:       "/export",
+				Methods:    []string{"POST"},
+				Parameters: []Parameter{{Name: "requestBody", In: "body"}},
+			},
+			{
+				Url:        "http://example.com/internal/api/reports/1",
+				Path:       "/reports/{year}",
+				Methods:    []string{"GET"},
+				Parameters: []Parameter{{Name: "year", In: "path", Required: true}},
+			},
+		}
+
+		if spec.Version != "3.0.1" || !reflect.DeepEqual(expected, spec.Endpoints) {
+			t.Errorf("Parse; want %+v; got %+v", expected, spec.Endpoints)
+		}
+	})
+
+	t.Run("not a spec", func(t *testing.T) {
+		for _, body := range []string{`<html><body>Not found</body></html>`, `{"name": "package", "paths": {}}`, `{"openapi": "3.0.0"}`} {
+			if _, err := Parse("http://example.com/swagger.json", []byte(body)); err == nil {
+				t.Errorf("Parse(%q); want error; got nil", body)
+			}
+		}
+	})
+}
+
+func TestFinder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/swagger.json":
+			// Single page applications answer every path with their index
+			fmt.Fprint(w, "<html><body><div id=\"app\"></div></body></html>")
+		case "/v2/api-docs":
+			fmt.Fprint(w, `{"swagger": "2.0", "paths": {"/health": {"get": {}}}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	limiter := client.NewRateLimiter(1000)
+	defer limiter.Stop()
+
+	finder := NewFinder(client.NewClient(client.ClientConfig{}), limiter)
+
+	spec := finder.Find(server.URL + "/")
+
+	if spec == nil {
+		t.Fatal("Find; want spec; got nil")
+	}
+
+	if spec.Url != server.URL+"/v2/api-docs" || len(spec.Endpoints) != 1 || spec.Endpoints[0].Url != server.URL+"/health" {
+		t.Errorf("Find; want spec on /v2/api-docs with /health endpoint; got %+v", *spec)
+	}
+}
@@ -0,0 +1,70 @@
+package archive
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+const (
+	IndexFile       = "index.jsonl"
+	BodiesDirectory = "bodies"
+)
+
+// Line of the index file, bodies are stored apart so that identical responses are only saved once
+type IndexEntry struct {
+	Url            string      `json:"url"`
+	Method         string      `json:"method"`
+	RequestHeaders http.Header `json:"request_headers,omitempty"`
+	StatusCode     int         `json:"status"`
+	Headers        http.Header `json:"headers,omitempty"`
+
+	// Body path relative to the archive directory, named after the SHA-256 of its content
+	Body string `json:"body"`
+}
+
+func readDirectory(path string) (*Archive, error) {
+	file, err := os.Open(filepath.Join(path, IndexFile))
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to open archive index. Reason: %s", err.Error())
+	}
+
+	defer file.Close()
+
+	archive := newArchive()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 16*1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		var index IndexEntry
+
+		if err := json.Unmarshal(scanner.Bytes(), &index); err != nil {
+			return nil, fmt.Errorf("unable to parse archive index on line %d. Reason: %s", line, err.Error())
+		}
+
+		body, err := os.ReadFile(filepath.Join(path, index.Body))
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to read archived body of %q. Reason: %s", index.Url, err.Error())
+		}
+
+		archive.add(&Entry{
+			Url:           index.Url,
+			Method:        index.Method,
+			RequestHeader: index.RequestHeaders,
+			StatusCode:    index.StatusCode,
+			Header:        index.Headers,
+			Body:          body,
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read archive index. Reason: %s", err.Error())
+	}
+
+	return archive, nil
+}
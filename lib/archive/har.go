@@ -0,0 +1,83 @@
+package archive
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// Subset of the HTTP Archive format (http://www.softwareishard.com/blog/har-12-spec/) used for replaying
+type har struct {
+	Log struct {
+		Entries []struct {
+			Request struct {
+				Method  string      `json:"method"`
+				Url     string      `json:"url"`
+				Headers []harHeader `json:"headers"`
+			} `json:"request"`
+			Response struct {
+				Status  int         `json:"status"`
+				Headers []harHeader `json:"headers"`
+				Content struct {
+					Text     string `json:"text"`
+					Encoding string `json:"encoding"`
+				} `json:"content"`
+			} `json:"response"`
+		} `json:"entries"`
+	} `json:"log"`
+}
+
+type harHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func readHar(path string) (*Archive, error) {
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to open HAR file. Reason: %s", err.Error())
+	}
+
+	var content har
+	if err := json.Unmarshal(data, &content); err != nil {
+		return nil, fmt.Errorf("unable to parse HAR file. Reason: %s", err.Error())
+	}
+
+	archive := newArchive()
+
+	for _, item := range content.Log.Entries {
+		body := []byte(item.Response.Content.Text)
+
+		if item.Response.Content.Encoding == "base64" {
+			body, err = base64.StdEncoding.DecodeString(item.Response.Content.Text)
+
+			if err != nil {
+				return nil, fmt.Errorf("unable to decode HAR body of %q. Reason: %s", item.Request.Url, err.Error())
+			}
+		}
+
+		archive.add(&Entry{
+			Url:           item.Request.Url,
+			Method:        item.Request.Method,
+			RequestHeader: harHeaders(item.Request.Headers),
+			StatusCode:    item.Response.Status,
+			Header:        harHeaders(item.Response.Headers),
+			Body:          body,
+		})
+	}
+
+	return archive, nil
+}
+
+func harHeaders(headers []harHeader) http.Header {
+	result := make(http.Header)
+
+	for _, header := range headers {
+		result.Add(header.Name, header.Value)
+	}
+
+	return result
+}
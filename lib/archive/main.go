@@ -0,0 +1,78 @@
+package archive
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Entry is a single request and response pair saved by a previous run or by another tool
+type Entry struct {
+	Url           string
+	Method        string
+	RequestHeader http.Header
+	StatusCode    int
+	Header        http.Header
+	Body          []byte
+}
+
+// Archive holds saved responses in memory, indexed by the requested URL
+type Archive struct {
+	entries map[string]*Entry
+	urls    []string
+}
+
+func newArchive() *Archive {
+	return &Archive{
+		entries: make(map[string]*Entry),
+	}
+}
+
+// Opens saved responses from disk, the format is detected from the path:
+//   - directory: responses stored by Writer (index.jsonl and content addressed bodies)
+//   - .har: HTTP Archive exported by browsers and proxies
+//   - .warc or .warc.gz: Web ARChive, only response records are used
+func Open(path string) (*Archive, error) {
+	info, err := os.Stat(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to open archive. Reason: %s", err.Error())
+	}
+
+	switch {
+	case info.IsDir():
+		return readDirectory(path)
+	case strings.HasSuffix(path, ".har"):
+		return readHar(path)
+	case strings.HasSuffix(path, ".warc"), strings.HasSuffix(path, ".warc.gz"):
+		return readWarc(path)
+	default:
+		return nil, fmt.Errorf("unable to open archive %q. Reason: Unknown format, expected a directory, .har or .warc file", path)
+	}
+}
+
+func (archive *Archive) add(entry *Entry) {
+	// Only GET responses are replayed, since that's what the evaluation requests
+	if entry.Method != "" && entry.Method != http.MethodGet {
+		return
+	}
+
+	if _, exists := archive.entries[entry.Url]; !exists {
+		archive.urls = append(archive.urls, entry.Url)
+	}
+
+	// Later responses for the same URL replace earlier ones
+	archive.entries[entry.Url] = entry
+}
+
+func (archive *Archive) Get(url string) (*Entry, bool) {
+	entry, found := archive.entries[url]
+
+	return entry, found
+}
+
+// Lists every archived URL, in the order they were first saved
+func (archive *Archive) Urls() []string {
+	return archive.urls
+}
@@ -0,0 +1,200 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestOpen(t *testing.T) {
+	writeFile := func(t *testing.T, dir string, name string, content string) string {
+		path := filepath.Join(dir, name)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		return path
+	}
+
+	assert := func(t *testing.T, archive *Archive, url string, status int, body string) {
+		entry, found := archive.Get(url)
+
+		if !found {
+			t.Fatalf("Get; want entry for %s; got none (urls %v)", url, archive.Urls())
+		}
+
+		if entry.StatusCode != status || string(entry.Body) != body {
+			t.Errorf("Get; want %d %q; got %d %q", status, body, entry.StatusCode, string(entry.Body))
+		}
+	}
+
+	t.Run("directory", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "bodies/abc", "<p>login</p>")
+		writeFile(t, dir, IndexFile, strings.Join([]string{
+			`{"url":"http://localhost/login","method":"GET","status":200,"headers":{"Content-Type":["text/html"]},"body":"bodies/abc"}`,
+			`{"url":"http://localhost/missing","method":"GET","status":404,"body":"bodies/abc"}`,
+		}, "\n"))
+
+		archive, err := Open(dir)
+		if err != nil {
+			t.Fatalf("Open; unexpected error %s", err)
+		}
+
+		assert(t, archive, "http://localhost/login", 200, "<p>login</p>")
+		assert(t, archive, "http://localhost/missing", 404, "<p>login</p>")
+	})
+
+	t.Run("har", func(t *testing.T) {
+		path := writeFile(t, t.TempDir(), "capture.har", `{"log": {"entries": [
+			{"request": {"method": "GET", "url": "http://localhost/search", "headers": []},
+			 "response": {"status": 200, "headers": [{"name": "Content-Type", "value": "text/html"}], "content": {"text": "<p>search</p>"}}},
+			{"request": {"method": "GET", "url": "http://localhost/logo", "headers": []},
+			 "response": {"status": 200, "headers": [], "content": {"text": "aGVsbG8=", "encoding": "base64"}}},
+			{"request": {"method": "POST", "url": "http://localhost/login", "headers": []},
+			 "response": {"status": 302, "headers": [], "content": {"text": ""}}}
+		]}}`)
+
+		archive, err := Open(path)
+		if err != nil {
+			t.Fatalf("Open; unexpected error %s", err)
+		}
+
+		assert(t, archive, "http://localhost/search", 200, "<p>search</p>")
+		assert(t, archive, "http://localhost/logo", 200, "hello")
+
+		if _, found := archive.Get("http://localhost/login"); found {
+			t.Error("Get; want POST requests to be ignored")
+		}
+	})
+
+	t.Run("warc", func(t *testing.T) {
+		response := "HTTP/1.1 200 OK\r\nContent-Type: text/html\r\nContent-Length: 14\r\n\r\n<p>logout</p>\n"
+		request := "GET /logout HTTP/1.1\r\nHost: localhost\r\n\r\n"
+
+		record := func(kind string, contentType string, block string) string {
+			return "WARC/1.0\r\n" +
+				"WARC-Type: " + kind + "\r\n" +
+				"WARC-Target-URI: http://localhost/logout\r\n" +
+				"Content-Type: " + contentType + "\r\n" +
+				"Content-Length: " + strconv.Itoa(len(block)) + "\r\n\r\n" +
+				block + "\r\n\r\n"
+		}
+
+		path := writeFile(t, t.TempDir(), "crawl.warc",
+			record("request", "application/http; msgtype=request", request)+
+				record("response", "application/http; msgtype=response", response))
+
+		archive, err := Open(path)
+		if err != nil {
+			t.Fatalf("Open; unexpected error %s", err)
+		}
+
+		assert(t, archive, "http://localhost/logout", 200, "<p>logout</p>\n")
+	})
+
+	t.Run("warc with gzip encoded body", func(t *testing.T) {
+		var compressed bytes.Buffer
+		writer := gzip.NewWriter(&compressed)
+		writer.Write([]byte("<p>account</p>"))
+		writer.Close()
+
+		response := "HTTP/1.1 200 OK\r\nContent-Encoding: gzip\r\nContent-Length: " + strconv.Itoa(compressed.Len()) + "\r\n\r\n" + compressed.String()
+		path := writeFile(t, t.TempDir(), "crawl.warc", "WARC/1.0\r\n"+
+			"WARC-Type: response\r\n"+
+			"WARC-Target-URI: http://localhost/account\r\n"+
+			"Content-Type: application/http; msgtype=response\r\n"+
+			"Content-Length: "+strconv.Itoa(len(response))+"\r\n\r\n"+
+			response+"\r\n\r\n")
+
+		archive, err := Open(path)
+		if err != nil {
+			t.Fatalf("Open; unexpected error %s", err)
+		}
+
+		assert(t, archive, "http://localhost/account", 200, "<p>account</p>")
+	})
+
+	t.Run("written by writer", func(t *testing.T) {
+		dir := t.TempDir()
+
+		writer, err := NewWriter(dir)
+		if err != nil {
+			t.Fatalf("NewWriter; unexpected error %s", err)
+		}
+
+		entries := []*Entry{
+			{Url: "http://localhost/a", Method: "GET", StatusCode: 200, Body: []byte("same"), RequestHeader: http.Header{
+				"Authorization": {"Bearer abc"},
+				"X-Api-Key":     {"abc"},
+				"User-Agent":    {"Mozilla/5.0"},
+			}, Header: http.Header{
+				"Set-Cookie":       {"PHPSESSID=abc; Path=/; HttpOnly"},
+				"X-Csrf-Token":     {"abc"},
+				"Www-Authenticate": {"Bearer realm=\"api\""},
+				"Content-Type":     {"text/html"},
+			}},
+			{Url: "http://localhost/b", Method: "GET", StatusCode: 200, Body: []byte("same")},
+			{Url: "http://localhost/c", Method: "GET", StatusCode: 500, Body: []byte("error")},
+		}
+
+		for _, entry := range entries {
+			if err := writer.Write(entry); err != nil {
+				t.Fatalf("Write; unexpected error %s", err)
+			}
+		}
+
+		writer.Close()
+
+		bodies, _ := os.ReadDir(filepath.Join(dir, BodiesDirectory))
+		if len(bodies) != 2 {
+			t.Errorf("Write; want identical bodies stored once; got %d bodies", len(bodies))
+		}
+
+		archive, err := Open(dir)
+		if err != nil {
+			t.Fatalf("Open; unexpected error %s", err)
+		}
+
+		assert(t, archive, "http://localhost/a", 200, "same")
+		assert(t, archive, "http://localhost/b", 200, "same")
+		assert(t, archive, "http://localhost/c", 500, "error")
+
+		entry, _ := archive.Get("http://localhost/a")
+		expected := http.Header{"Authorization": {RedactedValue}, "X-Api-Key": {RedactedValue}, "User-Agent": {"Mozilla/5.0"}}
+
+		if !reflect.DeepEqual(expected, entry.RequestHeader) {
+			t.Errorf("Write; want credentials redacted %v; got %v", expected, entry.RequestHeader)
+		}
+
+		expected = http.Header{
+			"Set-Cookie":       {"PHPSESSID=" + RedactedValue + "; Path=/; HttpOnly"},
+			"X-Csrf-Token":     {RedactedValue},
+			"Www-Authenticate": {"Bearer realm=\"api\""},
+			"Content-Type":     {"text/html"},
+		}
+
+		if !reflect.DeepEqual(expected, entry.Header) {
+			t.Errorf("Write; want response credentials redacted %v; got %v", expected, entry.Header)
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		path := writeFile(t, t.TempDir(), "input.txt", "http://localhost")
+
+		if _, err := Open(path); err == nil {
+			t.Error("Open; want error for unknown format")
+		}
+	})
+}
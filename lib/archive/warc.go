@@ -0,0 +1,153 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+)
+
+func readWarc(path string) (*Archive, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to open WARC file. Reason: %s", err.Error())
+	}
+
+	defer file.Close()
+
+	var reader io.Reader = file
+
+	// Compressed WARC files are a sequence of gzip members, which gzip.Reader reads as a single stream
+	if strings.HasSuffix(path, ".gz") {
+		reader, err = gzip.NewReader(file)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to decompress WARC file. Reason: %s", err.Error())
+		}
+	}
+
+	archive := newArchive()
+	buffered := bufio.NewReader(reader)
+
+	for {
+		entry, err := readWarcRecord(buffered)
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse WARC file. Reason: %s", err.Error())
+		}
+
+		if entry != nil {
+			archive.add(entry)
+		}
+	}
+
+	return archive, nil
+}
+
+// Reads the next record, returning nil for records that are not HTTP responses
+func readWarcRecord(reader *bufio.Reader) (*Entry, error) {
+	var version string
+
+	// Records are separated by empty lines
+	for version == "" {
+		line, err := reader.ReadString('\n')
+
+		if err != nil {
+			if err == io.EOF && strings.TrimSpace(line) == "" {
+				return nil, io.EOF
+			}
+
+			return nil, err
+		}
+
+		version = strings.TrimSpace(line)
+	}
+
+	if !strings.HasPrefix(version, "WARC/") {
+		return nil, fmt.Errorf("invalid record version %q", version)
+	}
+
+	headers, err := textproto.NewReader(reader).ReadMIMEHeader()
+
+	if err != nil {
+		return nil, err
+	}
+
+	length, err := strconv.ParseInt(headers.Get("Content-Length"), 10, 64)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid record length %q", headers.Get("Content-Length"))
+	}
+
+	block := make([]byte, length)
+
+	if _, err := io.ReadFull(reader, block); err != nil {
+		return nil, err
+	}
+
+	if headers.Get("WARC-Type") != "response" || !strings.HasPrefix(headers.Get("Content-Type"), "application/http") {
+		return nil, nil
+	}
+
+	response, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(block)), nil)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid HTTP response for %q. Reason: %s", headers.Get("WARC-Target-URI"), err.Error())
+	}
+
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+
+	body = decodeBody(response.Header, body)
+
+	return &Entry{
+		Url:        strings.Trim(headers.Get("WARC-Target-URI"), "<>"),
+		StatusCode: response.StatusCode,
+		Header:     response.Header,
+		Body:       body,
+	}, nil
+}
+
+// WARC records keep bodies as they were sent, while live responses are decompressed by the client. Bodies that
+// can't be decompressed are kept as they are
+func decodeBody(header http.Header, body []byte) []byte {
+	if !strings.EqualFold(header.Get("Content-Encoding"), "gzip") {
+		return body
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(body))
+
+	if err != nil {
+		return body
+	}
+
+	defer reader.Close()
+
+	decoded, err := io.ReadAll(reader)
+
+	if err != nil {
+		return body
+	}
+
+	// Same headers the client removes when it decompresses a response
+	header.Del("Content-Encoding")
+	header.Del("Content-Length")
+
+	return decoded
+}
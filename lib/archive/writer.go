@@ -0,0 +1,147 @@
+package archive
+
+import (
+	"bloodhound/lib/utils"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Value stored instead of header values that usually hold credentials
+const RedactedValue = "[redacted]"
+
+// Request headers are written with their values redacted when their name contains any of these words
+var sensitiveHeaderWords = []string{"authorization", "cookie", "token", "secret", "key", "session", "auth", "password"}
+
+// Response headers are matched by rules and technology signatures, so only the ones named like credentials are
+// redacted, and authentication challenges (WWW-Authenticate) are kept
+var sensitiveResponseHeaderWords = []string{"token", "secret", "api-key", "apikey", "session", "password"}
+
+// Writer stores request and response pairs on a directory that can later be replayed with Open
+type Writer struct {
+	path  string
+	index *os.File
+	mutex sync.Mutex
+}
+
+func NewWriter(path string) (*Writer, error) {
+	if err := os.MkdirAll(filepath.Join(path, BodiesDirectory), 0755); err != nil {
+		return nil, fmt.Errorf("unable to create archive directory. Reason: %s", err.Error())
+	}
+
+	// Index is appended to, so that a directory can collect responses from multiple runs
+	index, err := os.OpenFile(filepath.Join(path, IndexFile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to open archive index. Reason: %s", err.Error())
+	}
+
+	return &Writer{
+		path:  path,
+		index: index,
+	}, nil
+}
+
+func (writer *Writer) Write(entry *Entry) error {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+
+	// Bodies are content addressed, identical responses share the same file
+	hash := sha256.Sum256(entry.Body)
+	body := filepath.Join(BodiesDirectory, hex.EncodeToString(hash[:]))
+	bodyPath := filepath.Join(writer.path, body)
+
+	if _, err := os.Stat(bodyPath); os.IsNotExist(err) {
+		if err := os.WriteFile(bodyPath, entry.Body, 0644); err != nil {
+			return fmt.Errorf("unable to write archived body. Reason: %s", err.Error())
+		}
+	}
+
+	line, err := json.Marshal(IndexEntry{
+		Url:            entry.Url,
+		Method:         entry.Method,
+		RequestHeaders: redactHeaders(entry.RequestHeader),
+		StatusCode:     entry.StatusCode,
+		Headers:        redactResponseHeaders(entry.Header),
+		Body:           filepath.ToSlash(body),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.index.Write(append(line, '\n'))
+
+	return err
+}
+
+func (writer *Writer) Close() error {
+	return writer.index.Close()
+}
+
+// Custom request headers (--headers) often hold credentials, which shouldn't be shared with the archive
+func redactHeaders(header http.Header) http.Header {
+	if header == nil {
+		return nil
+	}
+
+	redacted := make(http.Header, len(header))
+
+	for name, values := range header {
+		if utils.ContainsAny(strings.ToLower(name), sensitiveHeaderWords) {
+			redacted[name] = []string{RedactedValue}
+		} else {
+			redacted[name] = values
+		}
+	}
+
+	return redacted
+}
+
+// Cookie values are redacted while their names and attributes are kept, since technologies are detected from them
+func redactResponseHeaders(header http.Header) http.Header {
+	if header == nil {
+		return nil
+	}
+
+	redacted := make(http.Header, len(header))
+
+	for name, values := range header {
+		switch {
+		case http.CanonicalHeaderKey(name) == "Set-Cookie":
+			for _, value := range values {
+				redacted[name] = append(redacted[name], redactCookie(value))
+			}
+		case utils.ContainsAny(strings.ToLower(name), sensitiveResponseHeaderWords):
+			redacted[name] = []string{RedactedValue}
+		default:
+			redacted[name] = values
+		}
+	}
+
+	return redacted
+}
+
+// Replaces the value of a Set-Cookie header (session=abc; Path=/) with RedactedValue (session=[redacted]; Path=/)
+func redactCookie(value string) string {
+	pair, attributes, _ := strings.Cut(value, ";")
+	name, _, found := strings.Cut(pair, "=")
+
+	if !found {
+		return RedactedValue
+	}
+
+	redacted := strings.TrimSpace(name) + "=" + RedactedValue
+
+	if attributes != "" {
+		redacted += ";" + attributes
+	}
+
+	return redacted
+}
@@ -40,12 +40,26 @@ func NewClient(config ClientConfig) *BloodhoundClient {
 }
 
 func (client *BloodhoundClient) Do(request *http.Request) (*http.Response, error) {
+	return client.do(client.client, request)
+}
+
+// Sends a request returning redirect responses as-is, instead of following them
+func (client *BloodhoundClient) DoWithoutRedirects(request *http.Request) (*http.Response, error) {
+	noRedirects := *client.client
+	noRedirects.CheckRedirect = func(request *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	return client.do(&noRedirects, request)
+}
+
+func (client *BloodhoundClient) do(httpClient *http.Client, request *http.Request) (*http.Response, error) {
 	// Add custom Headers
 	for key, value := range client.config.Headers {
 		request.Header.Set(key, value)
 	}
 
-	response, err := client.client.Do(request)
+	response, err := httpClient.Do(request)
 
 	if err != nil {
 		return nil, err
@@ -0,0 +1,49 @@
+package client
+
+import "time"
+
+// Each request needs to retrieve a token to be executed, and tokens are generated into the channel based on
+// the rate limiting. Meaning that every goroutine sharing the limiter will have to wait and respect the
+// configured rate limiting
+type RateLimiter struct {
+	tokens chan struct{}
+	done   chan struct{}
+}
+
+func NewRateLimiter(rate int) *RateLimiter {
+	limiter := &RateLimiter{
+		tokens: make(chan struct{}, rate),
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Second / time.Duration(rate))
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-limiter.done:
+				return
+			case <-ticker.C:
+			}
+
+			select {
+			case limiter.tokens <- struct{}{}:
+			case <-limiter.done:
+				return
+			}
+		}
+	}()
+
+	return limiter
+}
+
+// Blocks until a request is allowed by the rate limiter
+func (limiter *RateLimiter) Wait() {
+	<-limiter.tokens
+}
+
+// Stops generating tokens, the limiter can't be used after it
+func (limiter *RateLimiter) Stop() {
+	close(limiter.done)
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"bloodhound/lib/diff"
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/output"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffFormat string
+
+	diffCmd = &cobra.Command{
+		Use:   "diff <old> <new>",
+		Short: "Compare the json output of two runs, listing new, removed and changed targets",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			old, err := output.ReadJson(args[0])
+
+			if err != nil {
+				log.Fatalf("Failed to read old results. Reason: %s", err.Error())
+			}
+
+			current, err := output.ReadJson(args[1])
+
+			if err != nil {
+				log.Fatalf("Failed to read new results. Reason: %s", err.Error())
+			}
+
+			changes := diff.Compare(old, current)
+
+			switch output.Format(diffFormat) {
+			case output.JsonFormat:
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				err = encoder.Encode(changes)
+			case output.TextFormat:
+				printChanges(changes)
+			default:
+				err = fmt.Errorf("unknown output format: %s", diffFormat)
+			}
+
+			if err != nil {
+				log.Fatalf("Failed to write differences. Reason: %s", err.Error())
+			}
+		},
+	}
+)
+
+func init() {
+	diffCmd.Flags().StringVarP(&diffFormat, "format", "f", "text", "Output format: text (one line per changed target), json")
+	cmd.AddCommand(diffCmd)
+}
+
+// Records the differences with the baseline run on the results, when a baseline is given
+func applyBaseline(results []pipeline.Context) {
+	if baselineFile == "" {
+		return
+	}
+
+	baseline, err := output.ReadJson(baselineFile)
+
+	if err != nil {
+		log.Fatalf("Failed to read baseline results. Reason: %s", err.Error())
+	}
+
+	changes := diff.Apply(results, baseline, boostNew)
+
+	counts := make(map[string]int)
+	for _, change := range changes {
+		for _, kind := range change.Kinds {
+			counts[kind]++
+		}
+
+		if change.Is(diff.RemovedChange) {
+			log.WithFields(log.Fields{
+				"target": change.Url,
+			}).Debug("Target missing from this run")
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"new":     counts[diff.NewChange],
+		"removed": counts[diff.RemovedChange],
+		"status":  counts[diff.StatusChange],
+		"content": counts[diff.ContentChange],
+		"score":   counts[diff.ScoreChange],
+	}).Info("Finished comparing with baseline")
+}
+
+func printChanges(changes []diff.Change) {
+	for _, change := range changes {
+		switch {
+		case change.Is(diff.NewChange):
+			fmt.Printf("+ %s  score %g\n", change.Url, change.NewScore)
+		case change.Is(diff.RemovedChange):
+			fmt.Printf("- %s  score %g\n", change.Url, change.OldScore)
+		default:
+			fmt.Printf("~ %s  %s\n", change.Url, describeChange(change))
+		}
+	}
+}
+
+func describeChange(change diff.Change) string {
+	var details []string
+
+	if change.Is(diff.StatusChange) {
+		details = append(details, fmt.Sprintf("status %d → %d", change.OldStatus, change.NewStatus))
+	}
+
+	if change.Is(diff.ContentChange) {
+		details = append(details, "content changed")
+	}
+
+	if change.Is(diff.ScoreChange) {
+		details = append(details, fmt.Sprintf("score %g → %g (%+g)", change.OldScore, change.NewScore, change.ScoreDelta()))
+	}
+
+	return strings.Join(details, ", ")
+}
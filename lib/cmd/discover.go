@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"bloodhound/lib/discovery"
+	"bloodhound/lib/evaluator"
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/output"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	discoverTop        int
+	discoverWordlist   string
+	discoverExtensions []string
+	matchStatus        string
+	filterStatus       string
+	filterSize         string
+	filterWords        string
+	filterLines        string
+	noCalibration      bool
+	discoverInputFile  string
+	discoverOutputFile string
+	discoverFormat     string
+
+	discoverCmd = &cobra.Command{
+		Use:   "discover",
+		Short: "Brute-force paths on top-ranked URLs and evaluate the discovered resources",
+		Run: func(cmd *cobra.Command, args []string) {
+			// Input is usually the sorted output of a previous run, so the first lines are the top-ranked URLs
+			targetUrls, err := readInputFile(discoverInputFile)
+
+			if err != nil {
+				log.Fatalf("Failed to process input file. Reason: %s", err.Error())
+			}
+
+			if discoverTop > 0 && len(targetUrls) > discoverTop {
+				targetUrls = targetUrls[:discoverTop]
+			}
+
+			ruleset := loadRuleset()
+
+			format, err := output.ParseFormat(discoverFormat)
+
+			if err != nil {
+				log.Fatalf("Failed to parse output format. Reason: %s", err.Error())
+			}
+
+			words := discovery.DefaultWords()
+
+			if discoverWordlist != "" {
+				if words, err = discovery.ReadWords(discoverWordlist); err != nil {
+					log.Fatalf("Failed to process word list. Reason: %s", err.Error())
+				}
+			}
+
+			filter, err := parseDiscoveryFilter()
+
+			if err != nil {
+				log.Fatalf("Failed to parse discovery filters. Reason: %s", err.Error())
+			}
+
+			clientConfig := loadClientConfig()
+			bases := discovery.Bases(targetUrls)
+
+			log.WithFields(log.Fields{
+				"bases": len(bases),
+				"words": len(words),
+			}).Info("Initialized content discovery")
+
+			hits := discovery.Discover(bases, discovery.Config{
+				Client:     clientConfig,
+				Words:      words,
+				Extensions: discoverExtensions,
+				Filter:     filter,
+			})
+
+			var targets []pipeline.Context
+			for _, hit := range hits {
+				target := pipeline.NewContext(hit.Url)
+				target.DiscoveryHit = &hit.Response
+				target.AddSource(pipeline.NewSource(pipeline.BruteforceSource, "", fmt.Sprintf("HTTP %d", hit.Response.StatusCode)))
+				targets = append(targets, target)
+			}
+
+			// Discovered resources are ranked like any other input
+			results := evaluator.Evaluate(targets, ruleset, evaluator.Config{
+				Client:             clientConfig,
+				ProbeReflections:   probeReflections,
+				DiscoverParameters: discoverParameters,
+				ParameterWords:     loadParameterWords(),
+				ProbeGraphql:       probeGraphql,
+			})
+
+			if err := output.Write(format, discoverOutputFile, results); err != nil {
+				log.Fatalf("Failed to write to output file. Reason: %s", err.Error())
+			}
+		},
+	}
+)
+
+func init() {
+	discoverCmd.Flags().StringVarP(&discoverInputFile, "input", "i", "", "Input file with base URLs, usually the sorted output of a previous run (required)")
+	discoverCmd.MarkFlagRequired("input")
+
+	discoverCmd.Flags().StringVarP(&discoverOutputFile, "output", "o", "discovered.txt", "Output file to write sorted list of discovered resources")
+	discoverCmd.Flags().StringVarP(&discoverFormat, "format", "f", "text", "Output format: text (sorted URLs), json (scores and matched rules grouped by category), html (self-contained report)")
+	discoverCmd.Flags().IntVar(&discoverTop, "top", 10, "Number of URLs from the top of the input file to use as bases, 0 uses every URL")
+	discoverCmd.Flags().StringVarP(&discoverWordlist, "wordlist", "w", "", "Word list with candidate paths, defaults to a built-in list of common paths")
+	discoverCmd.Flags().StringSliceVarP(&discoverExtensions, "extensions", "e", []string{}, "Extensions appended to each word (-e .php,.bak)")
+	discoverCmd.Flags().StringVar(&matchStatus, "mc", "", "Match status codes, comma separated with ranges (defaults to 200-204,301,302,307,308,401,403,405,500)")
+	discoverCmd.Flags().StringVar(&filterStatus, "fc", "", "Filter out status codes")
+	discoverCmd.Flags().StringVar(&filterSize, "fs", "", "Filter out response sizes")
+	discoverCmd.Flags().StringVar(&filterWords, "fw", "", "Filter out response word counts")
+	discoverCmd.Flags().StringVar(&filterLines, "fl", "", "Filter out response line counts")
+	discoverCmd.Flags().BoolVar(&noCalibration, "no-calibration", false, "Disable filtering responses similar to the ones of random paths")
+
+	addProbeFlags(discoverCmd)
+	cmd.AddCommand(discoverCmd)
+}
+
+func parseDiscoveryFilter() (discovery.Filter, error) {
+	filter := discovery.Filter{
+		MatchStatus:   discovery.DefaultMatchStatus,
+		AutoCalibrate: !noCalibration,
+	}
+
+	var err error
+
+	if matchStatus != "" {
+		if filter.MatchStatus, err = discovery.ParseNumbers(matchStatus); err != nil {
+			return filter, err
+		}
+	}
+
+	for _, option := range []struct {
+		value  string
+		target *[]int
+	}{
+		{filterStatus, &filter.FilterStatus},
+		{filterSize, &filter.FilterSize},
+		{filterWords, &filter.FilterWords},
+		{filterLines, &filter.FilterLines},
+	} {
+		if *option.target, err = discovery.ParseNumbers(option.value); err != nil {
+			return filter, err
+		}
+	}
+
+	return filter, nil
+}
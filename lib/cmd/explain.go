@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"bloodhound/lib/client"
+	"bloodhound/lib/discovery"
+	"bloodhound/lib/evaluator"
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/graphql"
+	"bloodhound/lib/rules"
+	"bloodhound/lib/tech"
+	"fmt"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	explainCmd = &cobra.Command{
+		Use:   "explain <url>",
+		Short: "Evaluate a single URL and show why each rule matched or not",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ruleset := loadRuleset()
+
+			context := pipeline.NewContext(args[0])
+			context.AddSource(pipeline.NewSource(pipeline.InputSource, "", "argument"))
+
+			if replay := openReplayArchive(); replay != nil {
+				var found bool
+
+				if context, found = pipeline.ReplayContext(replay, context); !found {
+					log.WithFields(log.Fields{
+						"target": context.Url,
+					}).Warn("Resource not found on archive: Only resource level rules will be explained")
+				}
+			} else {
+				var err error
+
+				clientConfig := loadClientConfig()
+				bloodhoundClient := client.NewClient(clientConfig)
+
+				if context, err = pipeline.FetchResource(bloodhoundClient, context); err != nil {
+					log.WithFields(log.Fields{
+						"target": context.Url,
+						"err":    err.Error(),
+					}).Warn("Unable to request URL: Only resource level rules will be explained")
+				} else {
+					limiter := client.NewRateLimiter(clientConfig.Rate)
+
+					if probeReflections {
+						context.Reflections = pipeline.NewReflectionProber(bloodhoundClient, limiter).Probe(context)
+					}
+
+					if probeGraphql && graphql.IsCandidate(context.Url) {
+						context.Graphql = graphql.NewAnalyzer(bloodhoundClient, limiter).Analyze(context.Url)
+					}
+
+					if discoverParameters && !evaluator.HasQuery(context.Url) {
+						finder := discovery.NewParameterFinder(bloodhoundClient, limiter, loadParameterWords())
+
+						context.Forms = evaluator.AnalyzeForms(context.Content, context.Url)
+						evaluator.HarvestParameters(finder, context)
+						context.Parameters = finder.Find(context.Url)
+					}
+
+					limiter.Stop()
+				}
+			}
+
+			printExplanation(evaluator.Explain(context, ruleset))
+		},
+	}
+)
+
+func init() {
+	addProbeFlags(explainCmd)
+	cmd.AddCommand(explainCmd)
+}
+
+func printExplanation(explanation evaluator.Explanation) {
+	context := explanation.Context
+
+	if explanation.Removed {
+		fmt.Printf("%s  removed\n", context.Url)
+	} else {
+		fmt.Printf("%s  score %g\n", context.Url, context.Score)
+	}
+
+	type levelResults struct {
+		level   rules.Level
+		title   string
+		results []evaluator.RuleExplanation
+	}
+
+	var levels []levelResults
+
+	// Resource and content levels are always shown, other levels only when the ruleset has rules for them
+	for _, level := range []levelResults{
+		{level: rules.ResourceLevel, title: "resource"},
+		{level: rules.ProvenanceLevel, title: "provenance"},
+		{level: rules.TechLevel, title: "tech"},
+		{level: rules.FindingLevel, title: "finding"},
+		{level: rules.FormLevel, title: "form"},
+		{level: rules.ReflectionLevel, title: "reflection"},
+		{level: rules.ParameterLevel, title: "parameter"},
+		{level: rules.GraphqlLevel, title: "graphql"},
+		{level: rules.JsonLevel, title: "json"},
+		{level: rules.ContentLevel, title: "content"},
+	} {
+		for _, result := range explanation.Rules {
+			if result.Rule.Level == level.level {
+				level.results = append(level.results, result)
+			}
+		}
+
+		if len(level.results) != 0 || level.level == rules.ResourceLevel || level.level == rules.ContentLevel {
+			levels = append(levels, level)
+		}
+	}
+
+	for i, level := range levels {
+		results := level.results
+
+		title := level.title
+		switch level.level {
+		case rules.TechLevel:
+			title += describeTechnologies(context.Technologies)
+		case rules.FindingLevel:
+			title += fmt.Sprintf(" (%d secrets found)", len(context.Findings))
+		case rules.FormLevel:
+			title += fmt.Sprintf(" (%d forms found)", len(context.Forms))
+		case rules.ReflectionLevel:
+			title += fmt.Sprintf(" (%d reflections found)", len(context.Reflections))
+		case rules.ParameterLevel:
+			title += fmt.Sprintf(" (%d hidden parameters found)", len(context.Parameters))
+		case rules.GraphqlLevel:
+			title += describeGraphql(context.Graphql)
+		case rules.JsonLevel:
+			if context.Json == nil {
+				title += " (not a JSON response)"
+			}
+		case rules.ProvenanceLevel:
+			title += " (" + strings.Join(slices.Compact(context.SourceKinds()), ", ") + ")"
+		case rules.ContentLevel:
+			title += describeResponse(context.Response)
+		}
+
+		last := i == len(levels)-1
+		fmt.Printf("%s%s\n", branch(last), title)
+
+		for j, result := range results {
+			printRuleExplanation(indent(last), result, j == len(results)-1)
+		}
+	}
+}
+
+func printRuleExplanation(prefix string, result evaluator.RuleExplanation, last bool) {
+	var status string
+
+	switch {
+	case result.Removed:
+		status = "✔ " + result.Rule.Name + "  removes target"
+	case result.Matched:
+		status = fmt.Sprintf("✔ %s  %+g", result.Rule.Name, result.Points)
+	default:
+		status = "✘ " + result.Rule.Name
+	}
+
+	if result.Rule.Category != "" {
+		status += "  [" + result.Rule.Category + "]"
+	}
+
+	fmt.Printf("%s%s%s\n", prefix, branch(last), status)
+
+	detail := result.Reason
+	if result.Matched {
+		detail = fmt.Sprintf("matched %s: %s", result.Match.Location, result.Match.Snippet)
+
+		if result.Line != 0 {
+			detail += fmt.Sprintf(" (line %d)", result.Line)
+		}
+	}
+
+	fmt.Printf("%s%s   %s\n", prefix, indent(last), detail)
+}
+
+func describeResponse(response *pipeline.Response) string {
+	if response == nil {
+		return " (not retrieved)"
+	}
+
+	description := fmt.Sprintf(" (HTTP %d", response.StatusCode)
+
+	if contentType := response.Header.Get("Content-Type"); contentType != "" {
+		description += ", " + strings.Split(contentType, ";")[0]
+	}
+
+	return description + ")"
+}
+
+func describeTechnologies(technologies []tech.Technology) string {
+	if len(technologies) == 0 {
+		return " (none detected)"
+	}
+
+	var names []string
+	for _, technology := range technologies {
+		names = append(names, technology.String())
+	}
+
+	return " (" + strings.Join(names, ", ") + ")"
+}
+
+func describeGraphql(summary *graphql.Summary) string {
+	if summary == nil {
+		return " (not confirmed)"
+	}
+
+	if !summary.Introspection {
+		return " (confirmed, introspection disabled)"
+	}
+
+	return fmt.Sprintf(" (introspection enabled, %d types)", summary.Types)
+}
+
+func branch(last bool) string {
+	if last {
+		return "└─ "
+	}
+
+	return "├─ "
+}
+
+func indent(last bool) string {
+	if last {
+		return "   "
+	}
+
+	return "│  "
+}
@@ -1,29 +1,54 @@
 package cmd
 
 import (
+	"bloodhound/lib/archive"
 	"bloodhound/lib/client"
+	"bloodhound/lib/discovery"
 	"bloodhound/lib/evaluator"
 	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/output"
 	"bloodhound/lib/rules"
+	"bloodhound/lib/wordlist"
 	"bufio"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
 var (
-	inputFile   string
-	rulesetFile string
-
-	outputFile     string
-	logLevelStr    string
-	requestRate    int
-	requestHeaders []string
-	proxyServer    string
+	inputFile    string
+	rulesetFiles []string
+
+	outputFile         string
+	outputFormat       string
+	includeTags        []string
+	excludeTags        []string
+	logLevelStr        string
+	requestRate        int
+	requestHeaders     []string
+	proxyServer        string
+	replayPath         string
+	storePath          string
+	writeWordlist      bool
+	crawlDepth         int
+	scopeHosts         []string
+	softNotFound       string
+	clusterResults     bool
+	probeReflections   bool
+	discoverParameters bool
+	parameterWordlist  string
+	probeGraphql       bool
+	discoverSpecs      bool
+	harvestRecon       bool
+	baselineFile       string
+	boostNew           float64
+	databasePath       string
 
 	// TODO: Add "passive" option, so that no request is made to the target, and only resource name is evaluated
 	cmd = &cobra.Command{
@@ -46,8 +71,24 @@ var (
 			log.SetLevel(level)
 		},
 		Run: func(cmd *cobra.Command, args []string) {
-			// Validate that input file exists
-			targetUrls, err := readInputFile(inputFile)
+			startedAt := time.Now()
+			replay := openReplayArchive()
+
+			// Validate that input file exists, saved responses can be used as input when replaying
+			var targets []pipeline.Context
+			var err error
+
+			if inputFile != "" {
+				targets, err = readInputTargets(inputFile)
+			} else if replay != nil {
+				for _, targetUrl := range replay.Urls() {
+					target := pipeline.NewContext(targetUrl)
+					target.AddSource(pipeline.NewSource(pipeline.ReplaySource, replayPath, ""))
+					targets = append(targets, target)
+				}
+			} else {
+				err = errors.New("missing input file, use --input or --replay")
+			}
 
 			if err != nil {
 				log.Fatalf("Failed to process input file. Reason: %s", err.Error())
@@ -55,119 +96,256 @@ var (
 			}
 
 			log.WithFields(log.Fields{
-				"size": len(targetUrls),
+				"size": len(targets),
 			}).Trace("Finished reading input file")
 
 			// Validate that rule file exists
-			ruleset, err := rules.NewRuleset(rulesetFile)
+			ruleset := loadRuleset()
+
+			format, err := output.ParseFormat(outputFormat)
 
 			if err != nil {
-				log.Fatalf("Failed to process ruleset file. Reason: %s", err.Error())
-				os.Exit(1)
+				log.Fatalf("Failed to parse output format. Reason: %s", err.Error())
 			}
 
-			log.WithFields(log.Fields{
-				"size": len(ruleset.Rules),
-			}).Trace("Finished reading ruleset file")
+			softNotFoundMode, err := pipeline.ParseSoftNotFoundMode(softNotFound)
+
+			if err != nil {
+				log.Fatalf("Failed to parse soft 404 mode. Reason: %s", err.Error())
+			}
 
 			// Parse client configurations
-			headers, err := parseCustomHeaders(requestHeaders)
+			clientConfig := loadClientConfig()
 
-			if err != nil {
-				log.Fatalf("Failed to parse customer headers. Reason: %s", err.Error())
-				os.Exit(1)
+			// Execute command
+			var store *archive.Writer
+
+			if storePath != "" {
+				store, err = archive.NewWriter(storePath)
+
+				if err != nil {
+					log.Fatalf("Failed to create response archive. Reason: %s", err.Error())
+				}
+
+				defer store.Close()
 			}
 
-			clientConfig := client.ClientConfig{
-				Rate:    requestRate,
-				Headers: headers,
-				Proxy:   proxyServer,
+			var words *wordlist.Collector
+
+			if writeWordlist {
+				words = wordlist.NewCollector()
 			}
 
-			log.WithFields(log.Fields{
-				"config": clientConfig,
-			}).Trace("Finished creating HTTP client configurations")
+			var scope *evaluator.Scope
 
-			// Execute command
-			results := evaluator.Evaluate(targetUrls, ruleset, clientConfig)
+			if len(scopeHosts) != 0 {
+				scope = evaluator.NewScope(scopeHosts)
+			}
+
+			results := evaluator.Evaluate(targets, ruleset, evaluator.Config{
+				Client:             clientConfig,
+				Replay:             replay,
+				Store:              store,
+				Words:              words,
+				Depth:              crawlDepth,
+				Scope:              scope,
+				SoftNotFound:       softNotFoundMode,
+				Cluster:            clusterResults,
+				ProbeReflections:   probeReflections,
+				DiscoverParameters: discoverParameters,
+				ParameterWords:     loadParameterWords(),
+				ProbeGraphql:       probeGraphql,
+				DiscoverSpecs:      discoverSpecs,
+				Recon:              harvestRecon,
+			})
+
+			applyBaseline(results)
+			saveRun(startedAt, results)
 
 			// Write to output file
-			err = writeOutputFile(outputFile, results)
+			err = output.Write(format, outputFile, results)
 
 			if err != nil {
 				log.Fatalf("Failed to write to output file. Reason: %s", err.Error())
 			}
+
+			// Word list is written next to the output file
+			if words != nil {
+				wordlistFile := strings.TrimSuffix(outputFile, filepath.Ext(outputFile)) + ".wordlist.txt"
+
+				if err := words.WriteFile(wordlistFile); err != nil {
+					log.Fatalf("Failed to write word list file. Reason: %s", err.Error())
+				}
+
+				log.WithFields(log.Fields{
+					"file": wordlistFile,
+					"size": len(words.Words()),
+				}).Info("Finished writing word list")
+			}
 		},
 	}
 )
 
 func init() {
 	// Mandatory fields
-	cmd.PersistentFlags().StringVarP(&inputFile, "input", "i", "", "Input file with a list of URLs to process (required)")
-	cmd.MarkFlagRequired("input")
+	cmd.PersistentFlags().StringVarP(&inputFile, "input", "i", "", "Input file with a list of URLs to process (required, unless replaying saved responses)")
 
-	cmd.PersistentFlags().StringVarP(&rulesetFile, "rules", "r", "", "Ruleset file with rules and scores (required)")
+	cmd.PersistentFlags().StringSliceVarP(&rulesetFiles, "rules", "r", []string{}, "Ruleset files, directories or built-in rulesets (builtin:auth,builtin:upload), can be used multiple times (required)")
 	cmd.MarkFlagRequired("rules")
 
 	// Optional fields
 	cmd.PersistentFlags().StringVarP(&outputFile, "output", "o", "output.txt", "Output file to write sorted list")
+	cmd.Flags().StringVarP(&outputFormat, "format", "f", "text", "Output format: text (sorted URLs), json (scores and matched rules grouped by category), html (self-contained report)")
+	cmd.PersistentFlags().StringSliceVar(&includeTags, "include-tags", []string{}, "Only use rules with any of these tags or categories (--include-tags auth,upload)")
+	cmd.PersistentFlags().StringSliceVar(&excludeTags, "exclude-tags", []string{}, "Ignore rules with any of these tags or categories")
 	cmd.PersistentFlags().StringVarP(&logLevelStr, "log-level", "l", "info", "Set log level: trace, debug, info, warn, error, fatal, panic")
 	cmd.PersistentFlags().IntVarP(&requestRate, "rate", "R", 100, "Number of HTTP requests allowed during a single second on each thread")
 	cmd.PersistentFlags().StringArrayVarP(&requestHeaders, "headers", "H", []string{}, "Customer headers to be used when sending HTTP requests (--header \"User-Agent: Mozilla/5.0\")")
 	cmd.PersistentFlags().StringVarP(&proxyServer, "proxy", "P", "", "Proxy server in URL format (http://localhost:8080)")
+	cmd.Flags().IntVarP(&crawlDepth, "depth", "d", 0, "Number of link levels to follow from input targets, links found on evaluated pages are evaluated too")
+	cmd.Flags().StringSliceVar(&scopeHosts, "scope", []string{}, "Hosts that discovered links are allowed on, supports wildcards (--scope example.com,*.example.com), defaults to input hosts")
+	cmd.Flags().BoolVar(&writeWordlist, "wordlist", false, "Generate a target specific word list from evaluated content, written next to the output file (output.wordlist.txt)")
+	cmd.Flags().StringVar(&storePath, "store-responses", "", "Directory to store every request and response pair, including non-OK responses, can be replayed with --replay (headers and cookie values holding credentials are redacted)")
+	cmd.Flags().StringVar(&softNotFound, "soft-404", "off", "What to do with responses matching the \"not found\" response of their host, detected by requesting random paths: penalize, drop, off")
+	cmd.Flags().BoolVar(&clusterResults, "cluster", false, "Only output the highest ranked target of near-duplicate pages, text output shows how many were collapsed, json and html outputs list them")
+	addProbeFlags(cmd)
+	cmd.Flags().BoolVar(&discoverSpecs, "discover-specs", false, "Look for OpenAPI and Swagger specs on well-known locations of each input host, and evaluate the endpoints they describe")
+	cmd.Flags().BoolVar(&harvestRecon, "recon", false, "Read robots.txt, sitemaps, security.txt and crossdomain.xml of each input host, and evaluate the URLs found on them")
+	cmd.Flags().StringVar(&baselineFile, "baseline", "", "json output of a previous run to compare with, differences are logged and included in json output")
+	cmd.Flags().Float64Var(&boostNew, "boost-new", 1, "Multiply the score used to rank targets missing from the baseline by this value, the score on the output is not boosted")
+	cmd.Flags().StringVar(&databasePath, "db", "", "SQLite database file to store the run on (created when missing), can be queried with \"bloodhound query\"")
+	cmd.PersistentFlags().StringVar(&replayPath, "replay", "", "Evaluate saved responses instead of requesting targets (directory of saved responses, .har or .warc file)")
 }
 
-func readInputFile(inputFile string) ([]string, error) {
-	file, err := os.Open(inputFile)
+// Active probes send extra requests to evaluated targets, only commands that evaluate targets accept them
+func addProbeFlags(command *cobra.Command) {
+	command.Flags().BoolVar(&probeReflections, "probe-reflections", false, "Send a variant of targets with query parameters, with a unique canary on each parameter, to find where parameters are reflected")
+	command.Flags().BoolVar(&discoverParameters, "discover-params", false, "Find hidden query parameters of targets without any, using a word list and names found on forms and scripts of the same host")
+	command.Flags().StringVar(&parameterWordlist, "params-wordlist", "", "Word list of parameter names used by --discover-params, defaults to a built-in list")
+	command.Flags().BoolVar(&probeGraphql, "probe-graphql", false, "Confirm targets that look like GraphQL endpoints with a __typename query, and summarize their schema when introspection is enabled")
+}
+
+func loadRuleset() *rules.Ruleset {
+	if len(rulesetFiles) == 0 {
+		log.Fatal("Missing ruleset, use --rules with a ruleset file or a built-in ruleset (see \"bloodhound rules list\")")
+	}
+
+	ruleset, err := rules.LoadRulesets(rulesetFiles)
+
 	if err != nil {
-		return nil, errors.New("unable to open input file")
+		log.Fatalf("Failed to process ruleset file. Reason: %s", err.Error())
 	}
 
-	defer file.Close()
+	ruleset.FilterTags(includeTags, excludeTags)
 
-	var targetUrls []string
-	scanner := bufio.NewScanner(file)
+	if len(ruleset.Rules) == 0 {
+		log.Fatal("No rules left to evaluate after filtering by tags")
+	}
 
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line != "" {
-			score := line
-			targetUrls = append(targetUrls, score)
-		}
+	log.WithFields(log.Fields{
+		"size": len(ruleset.Rules),
+	}).Trace("Finished reading ruleset file")
+
+	return ruleset
+}
+
+func loadClientConfig() client.ClientConfig {
+	headers, err := parseCustomHeaders(requestHeaders)
+
+	if err != nil {
+		log.Fatalf("Failed to parse customer headers. Reason: %s", err.Error())
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, errors.New("unable to read input file")
+	clientConfig := client.ClientConfig{
+		Rate:    requestRate,
+		Headers: headers,
+		Proxy:   proxyServer,
+	}
+
+	log.WithFields(log.Fields{
+		"config": clientConfig,
+	}).Trace("Finished creating HTTP client configurations")
+
+	return clientConfig
+}
+
+// Built-in parameter names are used when no word list is given
+func loadParameterWords() []string {
+	if parameterWordlist == "" {
+		return discovery.DefaultParameterWords()
+	}
+
+	words, err := discovery.ReadWords(parameterWordlist)
+
+	if err != nil {
+		log.Fatalf("Failed to read parameter word list. Reason: %s", err.Error())
+	}
+
+	return words
+}
+
+func openReplayArchive() *archive.Archive {
+	if replayPath == "" {
+		return nil
+	}
+
+	replay, err := archive.Open(replayPath)
+
+	if err != nil {
+		log.Fatalf("Failed to open saved responses. Reason: %s", err.Error())
+	}
+
+	log.WithFields(log.Fields{
+		"size": len(replay.Urls()),
+	}).Trace("Finished reading saved responses")
+
+	return replay
+}
+
+func readInputFile(inputFile string) ([]string, error) {
+	targets, err := readInputTargets(inputFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var targetUrls []string
+	for _, target := range targets {
+		targetUrls = append(targetUrls, target.Url)
 	}
 
 	return targetUrls, nil
 }
 
-// TODO: Write to /temp if unable to write to configured output
-func writeOutputFile(outputFile string, results []pipeline.Context) error {
-	file, err := os.Create(outputFile)
+// Targets keep the line of the input file they were read from as their source
+func readInputTargets(inputFile string) ([]pipeline.Context, error) {
+	file, err := os.Open(inputFile)
 	if err != nil {
-		return errors.New("unable to create output file")
+		return nil, errors.New("unable to open input file")
 	}
 
 	defer file.Close()
 
-	writer := bufio.NewWriter(file)
+	var targets []pipeline.Context
+	scanner := bufio.NewScanner(file)
 
-	for _, result := range results {
-		_, err = writer.WriteString(result.Url)
+	for number := 1; scanner.Scan(); number++ {
+		// Text output of clustered runs has the number of near-duplicates after a tab
+		line, _, _ := strings.Cut(scanner.Text(), "\t")
+		line = strings.TrimSpace(line)
 
-		if err != nil {
-			return err
+		if line != "" {
+			target := pipeline.NewContext(line)
+			target.AddSource(pipeline.NewInputSource(inputFile, number))
+			targets = append(targets, target)
 		}
-
-		writer.WriteString("\n")
 	}
 
-	file.Sync()
-	writer.Flush()
+	if err := scanner.Err(); err != nil {
+		return nil, errors.New("unable to read input file")
+	}
 
-	return nil
+	return targets, nil
 }
 
 func parseLogLevel(level string) (log.Level, error) {
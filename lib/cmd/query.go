@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"bloodhound/lib/database"
+	"bloodhound/lib/evaluator/pipeline"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	queryRun          int64
+	queryCategory     string
+	queryLimit        int
+	queryUploads      bool
+	queryType         string
+	queryDatabasePath string
+
+	queryCmd = &cobra.Command{
+		Use:   "query",
+		Short: "Query results of previous runs stored with --db",
+	}
+
+	queryRunsCmd = &cobra.Command{
+		Use:   "runs",
+		Short: "List stored runs",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			printQuery(func(db *database.Database) (*database.Table, error) {
+				return db.Runs()
+			})
+		},
+	}
+
+	queryTopCmd = &cobra.Command{
+		Use:   "top",
+		Short: "List the highest scored targets of a run, ranked by the rules of a category when one is given",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			printQuery(func(db *database.Database) (*database.Table, error) {
+				return db.Top(queryRun, queryCategory, queryLimit)
+			})
+		},
+	}
+
+	queryFormsCmd = &cobra.Command{
+		Use:   "forms",
+		Short: "List pages with forms across every run",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			printQuery(func(db *database.Database) (*database.Table, error) {
+				return db.Forms(queryUploads)
+			})
+		},
+	}
+
+	queryFindingsCmd = &cobra.Command{
+		Use:   "findings",
+		Short: "List secrets found across every run",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			printQuery(func(db *database.Database) (*database.Table, error) {
+				return db.Findings(queryType)
+			})
+		},
+	}
+
+	querySqlCmd = &cobra.Command{
+		Use:   "sql <query>",
+		Short: "Run a read-only SQL query (tables: runs, targets, matches, findings, technologies, forms)",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			printQuery(func(db *database.Database) (*database.Table, error) {
+				return db.Query(args[0])
+			})
+		},
+	}
+)
+
+func init() {
+	queryCmd.PersistentFlags().StringVar(&queryDatabasePath, "db", "bloodhound.db", "Database file written with --db")
+	queryTopCmd.Flags().Int64Var(&queryRun, "run", 0, "Run ID (see \"bloodhound query runs\"), defaults to the latest run")
+	queryTopCmd.Flags().StringVar(&queryCategory, "category", "", "Only rank targets matching rules of this category")
+	queryTopCmd.Flags().IntVarP(&queryLimit, "limit", "n", 10, "Number of targets to list")
+	queryFormsCmd.Flags().BoolVar(&queryUploads, "uploads", false, "Only list forms with file fields")
+	queryFindingsCmd.Flags().StringVar(&queryType, "type", "", "Only list secrets of this type")
+
+	queryCmd.AddCommand(queryRunsCmd)
+	queryCmd.AddCommand(queryTopCmd)
+	queryCmd.AddCommand(queryFormsCmd)
+	queryCmd.AddCommand(queryFindingsCmd)
+	queryCmd.AddCommand(querySqlCmd)
+	cmd.AddCommand(queryCmd)
+}
+
+// Queries are run on a read-only connection, so custom queries can't change stored runs
+func printQuery(query func(db *database.Database) (*database.Table, error)) {
+	db, err := database.OpenReadOnly(queryDatabasePath)
+
+	if err != nil {
+		log.Fatalf("Failed to open database. Reason: %s", err.Error())
+	}
+
+	defer db.Close()
+
+	table, err := query(db)
+
+	if err != nil {
+		log.Fatalf("Failed to query database. Reason: %s", err.Error())
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, strings.ToUpper(strings.Join(table.Columns, "\t")))
+
+	for _, row := range table.Rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+
+	writer.Flush()
+}
+
+// Stores the results of the run, when a database is given
+func saveRun(startedAt time.Time, results []pipeline.Context) {
+	if databasePath == "" {
+		return
+	}
+
+	db, err := database.Open(databasePath)
+
+	if err != nil {
+		log.Fatalf("Failed to open database. Reason: %s", err.Error())
+	}
+
+	defer db.Close()
+
+	input := inputFile
+	if input == "" {
+		input = replayPath
+	}
+
+	runId, err := db.SaveRun(database.Run{
+		StartedAt: startedAt,
+		Input:     input,
+		Rulesets:  rulesetFiles,
+	}, results)
+
+	if err != nil {
+		log.Fatalf("Failed to store results on database. Reason: %s", err.Error())
+	}
+
+	log.WithFields(log.Fields{
+		"database": databasePath,
+		"run":      runId,
+		"size":     len(results),
+	}).Info("Finished storing results")
+}
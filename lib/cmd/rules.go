@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"bloodhound/lib/rules"
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rulesCmd = &cobra.Command{
+		Use:   "rules",
+		Short: "Inspect built-in rulesets",
+	}
+
+	rulesListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List built-in rulesets",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			for _, name := range rules.BuiltinNames() {
+				ruleset, err := rules.NewRuleset(rules.BuiltinPrefix + name)
+
+				if err != nil {
+					log.Fatalf("Failed to process built-in ruleset. Reason: %s", err.Error())
+				}
+
+				fmt.Printf("%s%-12s %-28s %2d rules  %s\n", rules.BuiltinPrefix, name, ruleset.Name, len(ruleset.Rules), ruleset.Description)
+			}
+		},
+	}
+
+	rulesShowCmd = &cobra.Command{
+		Use:   "show <name>",
+		Short: "Print the content of a built-in ruleset",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			data, err := rules.ReadBuiltin(args[0])
+
+			if err != nil {
+				log.Fatalf("Failed to read built-in ruleset. Reason: %s", err.Error())
+			}
+
+			os.Stdout.Write(data)
+		},
+	}
+)
+
+func init() {
+	rulesCmd.AddCommand(rulesListCmd)
+	rulesCmd.AddCommand(rulesShowCmd)
+	cmd.AddCommand(rulesCmd)
+}
@@ -0,0 +1,155 @@
+package database
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/output"
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+//go:embed schema.sql
+var schema string
+
+// Results of every run, stored on a single SQLite file
+type Database struct {
+	db *sql.DB
+}
+
+type Run struct {
+	StartedAt time.Time
+	Input     string
+	Rulesets  []string
+}
+
+// Opens the database file, creating it and its tables when missing
+func Open(path string) (*Database, error) {
+	db, err := sql.Open("sqlite", path)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to open database. Reason: %s", err.Error())
+	}
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("unable to create database tables. Reason: %s", err.Error())
+	}
+
+	return &Database{db: db}, nil
+}
+
+// Opens an existing database file, statements that change it are refused
+func OpenReadOnly(path string) (*Database, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro&_pragma=query_only(1)")
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to open database. Reason: %s", err.Error())
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("unable to open database. Reason: %s", err.Error())
+	}
+
+	return &Database{db: db}, nil
+}
+
+func (database *Database) Close() error {
+	return database.db.Close()
+}
+
+// Stores a run with every result, matched rule, finding, technology and form, returns the run ID
+func (database *Database) SaveRun(run Run, results []pipeline.Context) (int64, error) {
+	tx, err := database.db.Begin()
+
+	if err != nil {
+		return 0, fmt.Errorf("unable to start transaction. Reason: %s", err.Error())
+	}
+
+	defer tx.Rollback()
+
+	inserted, err := tx.Exec("INSERT INTO runs (started_at, input, rulesets) VALUES (?, ?, ?)",
+		run.StartedAt.UTC().Format(time.RFC3339), run.Input, strings.Join(run.Rulesets, ","))
+
+	if err != nil {
+		return 0, fmt.Errorf("unable to store run. Reason: %s", err.Error())
+	}
+
+	runId, _ := inserted.LastInsertId()
+
+	for _, context := range results {
+		if err := saveTarget(tx, runId, context); err != nil {
+			return 0, fmt.Errorf("unable to store %s. Reason: %s", context.Url, err.Error())
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("unable to commit run. Reason: %s", err.Error())
+	}
+
+	return runId, nil
+}
+
+func saveTarget(tx *sql.Tx, runId int64, context pipeline.Context) error {
+	result := output.NewResult(context)
+
+	var contentType sql.NullString
+	var size sql.NullInt64
+	var status sql.NullInt64
+	var hash sql.NullString
+
+	if context.Response != nil {
+		contentType = sql.NullString{String: context.Response.Header.Get("Content-Type"), Valid: true}
+		size = sql.NullInt64{Int64: int64(len(context.Response.Body)), Valid: true}
+		status = sql.NullInt64{Int64: int64(result.Status), Valid: true}
+		hash = sql.NullString{String: result.Hash, Valid: true}
+	}
+
+	inserted, err := tx.Exec("INSERT INTO targets (run_id, url, score, status, content_type, size, hash, depth) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		runId, context.Url, context.Score, status, contentType, size, hash, context.Depth)
+
+	if err != nil {
+		return err
+	}
+
+	targetId, _ := inserted.LastInsertId()
+
+	for _, match := range context.Matches {
+		category := match.Rule.Category
+		if category == "" {
+			category = output.UncategorizedCategory
+		}
+
+		if _, err := tx.Exec("INSERT INTO matches (target_id, rule, category, severity, value, tags) VALUES (?, ?, ?, ?, ?, ?)",
+			targetId, match.Rule.Name, category, string(match.Rule.Severity), match.Rule.Value, strings.Join(match.Rule.Tags, ",")); err != nil {
+			return err
+		}
+	}
+
+	for _, finding := range context.Findings {
+		if _, err := tx.Exec("INSERT INTO findings (target_id, type, location, preview) VALUES (?, ?, ?, ?)",
+			targetId, finding.Type, finding.Location, finding.Preview); err != nil {
+			return err
+		}
+	}
+
+	for _, technology := range context.Technologies {
+		if _, err := tx.Exec("INSERT INTO technologies (target_id, name, version) VALUES (?, ?, ?)",
+			targetId, technology.Name, technology.Version); err != nil {
+			return err
+		}
+	}
+
+	for _, form := range context.Forms {
+		if _, err := tx.Exec("INSERT INTO forms (target_id, action, method, enctype, cross_origin, has_csrf_token, has_file_field, has_password_field) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+			targetId, form.Action, form.Method, form.Enctype, form.CrossOrigin, form.HasCsrfToken, form.HasFileField, form.HasPasswordField); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package database
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/rules"
+	"bloodhound/lib/secrets"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newResult(url string, score float64, category string, form *pipeline.Form) pipeline.Context {
+	context := pipeline.NewContext(url)
+	context.Score = score
+	context.Response = &pipeline.Response{StatusCode: http.StatusOK, Header: http.Header{"Content-Type": {"text/html"}}, Body: []byte("<html></html>")}
+
+	rule := rules.NewRule(category+" rule", rules.ContentLevel, score, false, rules.RuleContent{})
+	rule.Category = category
+	context.Matches = []pipeline.Match{pipeline.NewMatch(rule, "element", "<form>")}
+
+	if form != nil {
+		context.Forms = []pipeline.Form{*form}
+	}
+
+	return context
+}
+
+func TestDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "runs.db")
+	db, err := Open(path)
+
+	if err != nil {
+		t.Fatalf("Open; got error %s", err.Error())
+	}
+
+	upload := &pipeline.Form{Action: "/upload", Method: "POST", HasFileField: true}
+	login := &pipeline.Form{Action: "/login", Method: "POST", HasPasswordField: true}
+
+	first := []pipeline.Context{
+		newResult("http://localhost/upload", 5, "upload", upload),
+		newResult("http://localhost/login", 3, "auth", login),
+	}
+
+	second := []pipeline.Context{
+		newResult("http://localhost/admin", 8, "auth", nil),
+		newResult("http://localhost/upload", 5, "upload", upload),
+		newResult("http://localhost/login", 4, "auth", login),
+	}
+	// Scores don't match rule values under weighted scoring, and targets without matches are ranked too
+	second[2].Score = 9
+	second = append(second, pipeline.NewContext("http://localhost/about"))
+	second[3].Score = 6
+	second[0].Findings = []secrets.Finding{{Type: "aws-access-key", Location: "script", Preview: "AKIA****"}}
+
+	for _, results := range [][]pipeline.Context{first, second} {
+		if _, err := db.SaveRun(Run{StartedAt: time.Now(), Input: "input.txt", Rulesets: []string{"builtin:auth"}}, results); err != nil {
+			t.Fatalf("SaveRun; got error %s", err.Error())
+		}
+	}
+
+	db.Close()
+
+	db, err = OpenReadOnly(path)
+
+	if err != nil {
+		t.Fatalf("OpenReadOnly; got error %s", err.Error())
+	}
+
+	defer db.Close()
+
+	assert := func(t testing.TB, name string, expected [][]string, table *Table, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("%s; got error %s", name, err.Error())
+		}
+
+		if !reflect.DeepEqual(expected, table.Rows) {
+			t.Errorf("%s; want %v; got %v", name, expected, table.Rows)
+		}
+	}
+
+	t.Run("runs", func(t *testing.T) {
+		table, err := db.Query("SELECT id, input, rulesets, (SELECT COUNT(*) FROM targets WHERE run_id = runs.id) FROM runs")
+		assert(t, "Query", [][]string{{"1", "input.txt", "builtin:auth", "2"}, {"2", "input.txt", "builtin:auth", "4"}}, table, err)
+	})
+
+	t.Run("top of the latest run", func(t *testing.T) {
+		table, err := db.Top(0, "", 3)
+		assert(t, "Top", [][]string{
+			{"http://localhost/login", "9", "200", "4", "auth rule"},
+			{"http://localhost/admin", "8", "200", "8", "auth rule"},
+			{"http://localhost/about", "6", "", "0", ""},
+		}, table, err)
+	})
+
+	t.Run("top by category", func(t *testing.T) {
+		table, err := db.Top(1, "auth", 10)
+		assert(t, "Top", [][]string{{"http://localhost/login", "3", "200", "3", "auth rule"}}, table, err)
+	})
+
+	t.Run("upload forms across runs", func(t *testing.T) {
+		table, err := db.Forms(true)
+		assert(t, "Forms", [][]string{{"http://localhost/upload", "POST", "/upload", "2", "2", "1", "0", "0"}}, table, err)
+	})
+
+	t.Run("findings", func(t *testing.T) {
+		table, err := db.Findings("aws-access-key")
+		assert(t, "Findings", [][]string{{"http://localhost/admin", "aws-access-key", "script", "AKIA****", "2", "2"}}, table, err)
+	})
+
+	t.Run("queries are read-only", func(t *testing.T) {
+		if _, err := db.Query("DELETE FROM runs"); err == nil {
+			t.Errorf("Query; want error; got nil")
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Result of a query, every value formatted as text
+type Table struct {
+	Columns []string
+	Rows    [][]string
+}
+
+// Runs any query, used for custom queries on the stored results
+func (database *Database) Query(query string, args ...any) (*Table, error) {
+	rows, err := database.db.Query(query, args...)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to run query. Reason: %s", err.Error())
+	}
+
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+
+	if err != nil {
+		return nil, err
+	}
+
+	table := &Table{Columns: columns}
+
+	for rows.Next() {
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("unable to read query results. Reason: %s", err.Error())
+		}
+
+		row := make([]string, len(columns))
+		for i, value := range values {
+			row[i] = formatValue(value)
+		}
+
+		table.Rows = append(table.Rows, row)
+	}
+
+	return table, rows.Err()
+}
+
+func formatValue(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(value)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	return fmt.Sprint(value)
+}
+
+// Runs with the number of targets of each one
+func (database *Database) Runs() (*Table, error) {
+	return database.Query(`
+		SELECT runs.id, runs.started_at, runs.input, runs.rulesets, COUNT(targets.id) AS targets
+		FROM runs LEFT JOIN targets ON targets.run_id = runs.id
+		GROUP BY runs.id
+		ORDER BY runs.id`)
+}
+
+// Highest scored targets of a run (the latest one when 0). When a category is given, only targets with matches
+// of the category are listed, ranked by the value of the rules of that category
+func (database *Database) Top(run int64, category string, limit int) (*Table, error) {
+	return database.Query(`
+		SELECT targets.url, targets.score, targets.status, COALESCE(SUM(matches.value), 0) AS rules_value, COALESCE(GROUP_CONCAT(matches.rule, '; '), '') AS rules
+		FROM targets LEFT JOIN matches ON matches.target_id = targets.id AND (?2 = '' OR matches.category = ?2)
+		WHERE targets.run_id = COALESCE(NULLIF(?1, 0), (SELECT MAX(id) FROM runs))
+		GROUP BY targets.id
+		HAVING ?2 = '' OR COUNT(matches.rule) > 0
+		ORDER BY CASE WHEN ?2 = '' THEN targets.score ELSE rules_value END DESC, targets.score DESC
+		LIMIT ?3`, run, category, limit)
+}
+
+// Pages with forms across every run, only forms with file fields when uploads is set
+func (database *Database) Forms(uploads bool) (*Table, error) {
+	return database.Query(`
+		SELECT targets.url, forms.method, forms.action, COUNT(DISTINCT targets.run_id) AS runs, MAX(targets.run_id) AS last_run,
+			MAX(forms.has_file_field) AS file, MAX(forms.has_password_field) AS password, MIN(forms.has_csrf_token) AS csrf
+		FROM forms JOIN targets ON targets.id = forms.target_id
+		WHERE ?1 = 0 OR forms.has_file_field = 1
+		GROUP BY targets.url, forms.method, forms.action
+		ORDER BY last_run DESC, targets.url`, uploads)
+}
+
+// Secrets found across every run, only secrets of the type when one is given
+func (database *Database) Findings(findingType string) (*Table, error) {
+	return database.Query(`
+		SELECT targets.url, findings.type, findings.location, findings.preview, MIN(targets.run_id) AS first_run, MAX(targets.run_id) AS last_run
+		FROM findings JOIN targets ON targets.id = findings.target_id
+		WHERE ?1 = '' OR findings.type = ?1
+		GROUP BY targets.url, findings.type, findings.location, findings.preview
+		ORDER BY last_run DESC, targets.url`, findingType)
+}
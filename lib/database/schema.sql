@@ -0,0 +1,56 @@
+CREATE TABLE IF NOT EXISTS runs (
+    id         INTEGER PRIMARY KEY,
+    started_at TEXT    NOT NULL,
+    input      TEXT    NOT NULL,
+    rulesets   TEXT    NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS targets (
+    id           INTEGER PRIMARY KEY,
+    run_id       INTEGER NOT NULL REFERENCES runs (id),
+    url          TEXT    NOT NULL,
+    score        REAL    NOT NULL,
+    status       INTEGER,
+    content_type TEXT,
+    size         INTEGER,
+    hash         TEXT,
+    depth        INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS targets_run ON targets (run_id);
+CREATE INDEX IF NOT EXISTS targets_url ON targets (url);
+
+CREATE TABLE IF NOT EXISTS matches (
+    target_id INTEGER NOT NULL REFERENCES targets (id),
+    rule      TEXT    NOT NULL,
+    category  TEXT    NOT NULL,
+    severity  TEXT,
+    value     REAL    NOT NULL,
+    tags      TEXT
+);
+
+CREATE INDEX IF NOT EXISTS matches_target ON matches (target_id);
+
+CREATE TABLE IF NOT EXISTS findings (
+    target_id INTEGER NOT NULL REFERENCES targets (id),
+    type      TEXT    NOT NULL,
+    location  TEXT    NOT NULL,
+    preview   TEXT    NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS technologies (
+    target_id INTEGER NOT NULL REFERENCES targets (id),
+    name      TEXT    NOT NULL,
+    version   TEXT
+);
+
+CREATE TABLE IF NOT EXISTS forms (
+    target_id          INTEGER NOT NULL REFERENCES targets (id),
+    action             TEXT    NOT NULL,
+    method             TEXT    NOT NULL,
+    enctype            TEXT,
+    cross_origin       INTEGER NOT NULL,
+    has_csrf_token     INTEGER NOT NULL,
+    has_file_field     INTEGER NOT NULL,
+    has_password_field INTEGER NOT NULL
+);
@@ -0,0 +1,156 @@
+package diff
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/output"
+	"bloodhound/lib/simhash"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+// Kinds of differences between two runs
+const (
+	NewChange     = "new"
+	RemovedChange = "removed"
+	StatusChange  = "status"
+	ContentChange = "content"
+	ScoreChange   = "score"
+)
+
+// Score differences below this are rounding noise of normalized scores
+const scoreTolerance = 0.001
+
+type Change struct {
+	Url   string   `json:"url"`
+	Kinds []string `json:"changes"`
+
+	OldStatus int     `json:"oldStatus,omitempty"`
+	NewStatus int     `json:"newStatus,omitempty"`
+	OldScore  float64 `json:"oldScore"`
+	NewScore  float64 `json:"newScore"`
+}
+
+func (change *Change) ScoreDelta() float64 {
+	return change.NewScore - change.OldScore
+}
+
+func (change *Change) Is(kind string) bool {
+	return slices.Contains(change.Kinds, kind)
+}
+
+// Differences of every target that changed, in the order of the new run, followed by targets missing from it.
+// Status and content are only compared when both runs retrieved the target
+func Compare(old []output.Result, current []output.Result) []Change {
+	previous := make(map[string]output.Result)
+	for _, result := range old {
+		previous[result.Url] = result
+	}
+
+	var changes []Change
+	present := make(map[string]bool)
+
+	for _, result := range current {
+		present[result.Url] = true
+
+		change := Change{
+			Url:       result.Url,
+			NewStatus: result.Status,
+			NewScore:  result.Score,
+		}
+
+		before, found := previous[result.Url]
+
+		if !found {
+			change.Kinds = []string{NewChange}
+			changes = append(changes, change)
+
+			continue
+		}
+
+		change.OldStatus = before.Status
+		change.OldScore = before.Score
+
+		if before.Status != 0 && result.Status != 0 && before.Status != result.Status {
+			change.Kinds = append(change.Kinds, StatusChange)
+		}
+
+		if contentChanged(before.Hash, result.Hash) {
+			change.Kinds = append(change.Kinds, ContentChange)
+		}
+
+		if math.Abs(change.ScoreDelta()) > scoreTolerance {
+			change.Kinds = append(change.Kinds, ScoreChange)
+		}
+
+		if len(change.Kinds) != 0 {
+			changes = append(changes, change)
+		}
+	}
+
+	for _, result := range old {
+		if !present[result.Url] {
+			changes = append(changes, Change{
+				Url:       result.Url,
+				Kinds:     []string{RemovedChange},
+				OldStatus: result.Status,
+				OldScore:  result.Score,
+			})
+		}
+	}
+
+	return changes
+}
+
+// Hashes are simhashes, so small differences (e.g. tokens, timestamps) aren't reported as changes
+func contentChanged(old string, current string) bool {
+	if old == "" || current == "" {
+		return false
+	}
+
+	a, errA := strconv.ParseUint(old, 16, 64)
+	b, errB := strconv.ParseUint(current, 16, 64)
+
+	if errA != nil || errB != nil {
+		return old != current
+	}
+
+	return !simhash.Similar(a, b)
+}
+
+// Records the differences with the baseline on the results, boosting the rank score of new targets, and ranks
+// them again. Scores aren't changed, since the output of this run is usually the baseline of the next one. Returns every change, including targets missing from the results
+func Apply(results []pipeline.Context, baseline []output.Result, boost float64) []Change {
+	var current []output.Result
+	for _, context := range results {
+		current = append(current, output.NewResult(context))
+	}
+
+	changes := Compare(baseline, current)
+
+	byUrl := make(map[string]Change)
+	for _, change := range changes {
+		byUrl[change.Url] = change
+	}
+
+	for i := range results {
+		change, found := byUrl[results[i].Url]
+
+		if !found {
+			continue
+		}
+
+		results[i].Changes = change.Kinds
+
+		if change.Is(NewChange) && boost > 0 {
+			results[i].Boost = boost
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].RankScore() > results[j].RankScore()
+	})
+
+	return changes
+}
@@ -0,0 +1,80 @@
+package diff
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/output"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	old := []output.Result{
+		{Url: "http://localhost/login", Score: 4, Status: 200, Hash: "00000000000000ff"},
+		{Url: "http://localhost/search", Score: 2, Status: 200, Hash: "0000000000000000"},
+		{Url: "http://localhost/about", Score: 1, Status: 200, Hash: "0000000000000000"},
+		{Url: "http://localhost/old", Score: 1, Status: 200},
+	}
+
+	current := []output.Result{
+		{Url: "http://localhost/admin", Score: 6, Status: 200},
+		{Url: "http://localhost/login", Score: 4, Status: 200, Hash: "00000000000000fe"},
+		{Url: "http://localhost/search", Score: 3.5, Status: 500, Hash: "ffffffff00000000"},
+		{Url: "http://localhost/about", Score: 1, Status: 200, Hash: "0000000000000000"},
+	}
+
+	expected := []Change{
+		{Url: "http://localhost/admin", Kinds: []string{NewChange}, NewStatus: 200, NewScore: 6},
+		{Url: "http://localhost/search", Kinds: []string{StatusChange, ContentChange, ScoreChange}, OldStatus: 200, NewStatus: 500, OldScore: 2, NewScore: 3.5},
+		{Url: "http://localhost/old", Kinds: []string{RemovedChange}, OldStatus: 200, OldScore: 1},
+	}
+
+	changes := Compare(old, current)
+
+	if !reflect.DeepEqual(expected, changes) {
+		t.Errorf("Compare; want %+v; got %+v", expected, changes)
+	}
+}
+
+func TestApply(t *testing.T) {
+	newContext := func(url string, score float64) pipeline.Context {
+		context := pipeline.NewContext(url)
+		context.Score = score
+		context.Response = &pipeline.Response{StatusCode: http.StatusOK, Body: []byte(fmt.Sprintf("<html>%s</html>", url))}
+
+		return context
+	}
+
+	results := []pipeline.Context{
+		newContext("http://localhost/login", 4),
+		newContext("http://localhost/admin", 3),
+	}
+
+	baseline := []output.Result{output.NewResult(results[0])}
+
+	changes := Apply(results, baseline, 2)
+
+	if len(changes) != 1 || changes[0].Url != "http://localhost/admin" {
+		t.Fatalf("Apply; want /admin as only change; got %+v", changes)
+	}
+
+	if results[0].Url != "http://localhost/admin" || results[0].Boost != 2 || !reflect.DeepEqual([]string{NewChange}, results[0].Changes) {
+		t.Errorf("Apply; want boosted new target ranked first; got %+v", results[0])
+	}
+
+	if results[0].Score != 3 {
+		t.Errorf("Apply; want unboosted score on new target; got %v", results[0].Score)
+	}
+
+	// The output of this run is the baseline of the next one, which must not see a score change
+	rerun := []output.Result{output.NewResult(newContext("http://localhost/admin", 3))}
+
+	if changes := Compare([]output.Result{output.NewResult(results[0])}, rerun); len(changes) != 0 {
+		t.Errorf("Compare; want no changes against the output of a boosted run; got %+v", changes)
+	}
+
+	if results[1].Changes != nil {
+		t.Errorf("Apply; want no changes on unchanged target; got %v", results[1].Changes)
+	}
+}
@@ -0,0 +1,83 @@
+package discovery
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Status codes matched by default, same as ffuf
+var DefaultMatchStatus = []int{200, 201, 202, 203, 204, 301, 302, 307, 308, 401, 403, 405, 500}
+
+type Filter struct {
+	// Responses must have one of these status codes
+	MatchStatus []int
+
+	// Responses with any of these values are dropped
+	FilterStatus []int
+	FilterSize   []int
+	FilterWords  []int
+	FilterLines  []int
+
+	// Drop responses that look like the calibration responses of their base
+	AutoCalibrate bool
+}
+
+func (filter *Filter) Matches(stats ResponseStats, baselines []ResponseStats) bool {
+	if len(filter.MatchStatus) != 0 && !slices.Contains(filter.MatchStatus, stats.StatusCode) {
+		return false
+	}
+
+	if slices.Contains(filter.FilterStatus, stats.StatusCode) ||
+		slices.Contains(filter.FilterSize, stats.Size) ||
+		slices.Contains(filter.FilterWords, stats.Words) ||
+		slices.Contains(filter.FilterLines, stats.Lines) {
+		return false
+	}
+
+	if filter.AutoCalibrate {
+		for _, baseline := range baselines {
+			// Dynamic "not found" pages often reflect the path, so size alone isn't enough
+			if stats.StatusCode == baseline.StatusCode && (stats.Size == baseline.Size || stats.Words == baseline.Words) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Parses comma separated lists of numbers and ranges (200,301-303)
+func ParseNumbers(value string) ([]int, error) {
+	var numbers []int
+
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+
+		if part == "" {
+			continue
+		}
+
+		start, end, isRange := strings.Cut(part, "-")
+
+		from, err := strconv.Atoi(start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", part)
+		}
+
+		to := from
+
+		if isRange {
+			if to, err = strconv.Atoi(end); err != nil || to < from {
+				return nil, fmt.Errorf("invalid range %q", part)
+			}
+		}
+
+		for number := from; number <= to; number++ {
+			numbers = append(numbers, number)
+		}
+	}
+
+	return numbers, nil
+}
@@ -0,0 +1,180 @@
+package discovery
+
+import (
+	"bloodhound/lib/client"
+	"bufio"
+	_ "embed"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+//go:embed wordlists/common.txt
+var commonWords string
+
+type Config struct {
+	Client client.ClientConfig
+
+	// Words appended to each base URL, and extensions appended to each word
+	Words      []string
+	Extensions []string
+
+	Filter Filter
+}
+
+// Hit is a candidate path that passed every filter
+type Hit struct {
+	Url      string
+	Response ResponseStats
+}
+
+// Built-in word list, used when no custom word list is given
+func DefaultWords() []string {
+	return strings.Fields(commonWords)
+}
+
+func ReadWords(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open word list. Reason: %s", err.Error())
+	}
+
+	defer file.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+
+		// Comments are common on published word lists
+		if word != "" && !strings.HasPrefix(word, "#") {
+			words = append(words, word)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read word list. Reason: %s", err.Error())
+	}
+
+	return words, nil
+}
+
+// Bases are the directories of the given URLs, so discovering from `https://example.com/app/login`
+// probes `https://example.com/app/<word>`
+func Bases(targetUrls []string) []string {
+	var bases []string
+
+	for _, targetUrl := range targetUrls {
+		parsed, err := url.Parse(targetUrl)
+
+		if err != nil || parsed.Host == "" {
+			continue
+		}
+
+		parsed.RawQuery = ""
+		parsed.Fragment = ""
+		parsed.Path = parsed.Path[:strings.LastIndex(parsed.Path, "/")+1]
+
+		if parsed.Path == "" {
+			parsed.Path = "/"
+		}
+
+		if base := parsed.String(); !slices.Contains(bases, base) {
+			bases = append(bases, base)
+		}
+	}
+
+	return bases
+}
+
+// Probes every word on every base URL, sharing one rate limiter across all requests
+func Discover(bases []string, config Config) []Hit {
+	limiter := client.NewRateLimiter(config.Client.Rate)
+	defer limiter.Stop()
+
+	prober := newProber(client.NewClient(config.Client), limiter)
+
+	var hits []Hit
+
+	for _, base := range bases {
+		baselines := prober.calibrate(base)
+
+		log.WithFields(log.Fields{
+			"base":      base,
+			"baselines": len(baselines),
+		}).Info("Started content discovery")
+
+		hits = append(hits, discoverBase(prober, base, baselines, config)...)
+	}
+
+	return hits
+}
+
+func discoverBase(prober *prober, base string, baselines []ResponseStats, config Config) []Hit {
+	candidates := make(chan string, len(config.Words))
+
+	go func() {
+		defer close(candidates)
+
+		for _, word := range config.Words {
+			word = strings.TrimPrefix(word, "/")
+			candidates <- base + word
+
+			for _, extension := range config.Extensions {
+				candidates <- base + word + extension
+			}
+		}
+	}()
+
+	var hits []Hit
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+
+	// TODO: Make this a configuration
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for candidate := range candidates {
+				stats, err := prober.probe(candidate)
+
+				if err != nil {
+					log.WithFields(log.Fields{
+						"target": candidate,
+						"err":    err.Error(),
+					}).Debug("Unable to probe candidate path")
+
+					continue
+				}
+
+				if !config.Filter.Matches(stats, baselines) {
+					continue
+				}
+
+				log.WithFields(log.Fields{
+					"target":     candidate,
+					"statusCode": stats.StatusCode,
+					"size":       stats.Size,
+					"words":      stats.Words,
+					"lines":      stats.Lines,
+				}).Info("Discovered resource")
+
+				mutex.Lock()
+				hits = append(hits, Hit{Url: candidate, Response: stats})
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return hits
+}
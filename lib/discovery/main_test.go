@@ -0,0 +1,72 @@
+package discovery
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBases(t *testing.T) {
+	bases := Bases([]string{
+		"http://localhost/app/login?next=/",
+		"http://localhost/app/logout",
+		"http://localhost",
+		"http://localhost/api/",
+		"not a url",
+	})
+
+	expected := []string{"http://localhost/app/", "http://localhost/", "http://localhost/api/"}
+
+	if !slices.Equal(expected, bases) {
+		t.Errorf("Bases; want %v; got %v", expected, bases)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	notFound := NewResponseStats(200, []byte("<p>Page /a1b2c3 not found</p>\n"))
+	baselines := []ResponseStats{notFound}
+
+	assert := func(t *testing.T, filter Filter, stats ResponseStats, expected bool) {
+		if actual := filter.Matches(stats, baselines); expected != actual {
+			t.Errorf("Matches(%+v); want %v; got %v", stats, expected, actual)
+		}
+	}
+
+	t.Run("match status", func(t *testing.T) {
+		filter := Filter{MatchStatus: DefaultMatchStatus}
+
+		assert(t, filter, NewResponseStats(403, nil), true)
+		assert(t, filter, NewResponseStats(404, nil), false)
+	})
+
+	t.Run("filter size, words and lines", func(t *testing.T) {
+		filter := Filter{FilterSize: []int{5}, FilterWords: []int{3}, FilterLines: []int{4}}
+
+		assert(t, filter, NewResponseStats(200, []byte("hello")), false)
+		assert(t, filter, NewResponseStats(200, []byte("one two three")), false)
+		assert(t, filter, NewResponseStats(200, []byte("a\nb\nc\nd")), false)
+		assert(t, filter, NewResponseStats(200, []byte("admin panel")), true)
+	})
+
+	t.Run("auto calibration", func(t *testing.T) {
+		filter := Filter{AutoCalibrate: true}
+
+		// Reflected path changes the size, but not the word count
+		assert(t, filter, NewResponseStats(200, []byte("<p>Page /administrator not found</p>\n")), false)
+		assert(t, filter, NewResponseStats(200, []byte("<h1>Admin</h1><form>login here</form>\n<p>welcome</p>")), true)
+		assert(t, filter, NewResponseStats(404, []byte("<p>Page /a1b2c3 not found</p>\n")), true)
+	})
+}
+
+func TestParseNumbers(t *testing.T) {
+	numbers, err := ParseNumbers("200, 301-303,500")
+
+	if err != nil || !slices.Equal([]int{200, 301, 302, 303, 500}, numbers) {
+		t.Errorf("ParseNumbers; want expanded list; got %v %v", numbers, err)
+	}
+
+	for _, invalid := range []string{"abc", "303-301", "200-x"} {
+		if _, err := ParseNumbers(invalid); err == nil {
+			t.Errorf("ParseNumbers(%q); want error", invalid)
+		}
+	}
+}
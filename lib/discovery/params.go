@@ -0,0 +1,263 @@
+package discovery
+
+import (
+	"bloodhound/lib/client"
+	_ "embed"
+	"net/url"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+//go:embed wordlists/params.txt
+var paramWords string
+
+const (
+	// Candidate parameters sent on each request, bigger batches need less requests but risk hitting URL length limits
+	parameterBatchSize = 40
+
+	// Requests sent while bisecting candidates of a single target
+	maxParameterRequests = 60
+)
+
+// Why a candidate parameter was considered accepted by the target
+const (
+	ReflectedParameter = "reflected"
+	ChangedParameter   = "changes response"
+)
+
+// Where a candidate parameter name came from
+const (
+	WordlistSource = "wordlist"
+	FormSource     = "form"
+	ScriptSource   = "script"
+)
+
+type Parameter struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+	Source string `json:"source"`
+}
+
+// Built-in parameter word list, used when no custom word list is given
+func DefaultParameterWords() []string {
+	return strings.Fields(paramWords)
+}
+
+// Finds query parameters accepted by targets that don't show any. Candidates are sent in batches with a unique
+// value each. Parameters whose value is reflected are found right away, and batches that change the response
+// compared to a baseline are split in halves until the parameter responsible for the change is found
+type ParameterFinder struct {
+	prober *prober
+	words  []string
+
+	// Names harvested from forms and scripts, by host
+	harvested map[string]map[string]string
+}
+
+func NewParameterFinder(client *client.BloodhoundClient, limiter *client.RateLimiter, words []string) *ParameterFinder {
+	return &ParameterFinder{
+		prober:    newProber(client, limiter),
+		words:     words,
+		harvested: make(map[string]map[string]string),
+	}
+}
+
+// Adds candidate names used on every target of the same host, the first source of a name is kept
+func (finder *ParameterFinder) Harvest(targetUrl string, names []string, source string) {
+	parsed, err := url.Parse(targetUrl)
+
+	if err != nil {
+		return
+	}
+
+	if finder.harvested[parsed.Host] == nil {
+		finder.harvested[parsed.Host] = make(map[string]string)
+	}
+
+	for _, name := range names {
+		if _, found := finder.harvested[parsed.Host][name]; !found && name != "" {
+			finder.harvested[parsed.Host][name] = source
+		}
+	}
+}
+
+func (finder *ParameterFinder) Find(targetUrl string) []Parameter {
+	parsed, err := url.Parse(targetUrl)
+
+	if err != nil {
+		return nil
+	}
+
+	names, sources := finder.candidates(parsed.Host)
+
+	search := parameterSearch{
+		finder: finder,
+		target: parsed,
+		budget: maxParameterRequests,
+	}
+
+	// Two baselines with random parameters tell whether the target responds the same way to the same request
+	first, echoed, err := search.request([]string{"bh" + randomPath()[:8]})
+	if err != nil {
+		return nil
+	}
+
+	second, _, err := search.request([]string{"bh" + randomPath()[:8]})
+	if err != nil {
+		return nil
+	}
+
+	if !sameResponse(first, second) {
+		log.WithFields(log.Fields{
+			"target": targetUrl,
+		}).Debug("Target responses are not stable: Skipping parameter discovery")
+
+		return nil
+	}
+
+	search.baseline = first
+
+	// Pages that echo the whole query string (canonical links, form actions) reflect any name
+	if len(echoed) != 0 {
+		log.WithFields(log.Fields{
+			"target": targetUrl,
+		}).Debug("Target reflects random parameters: Only checking response changes")
+
+		search.echoesQuery = true
+	}
+
+	for batch := range slices.Chunk(names, parameterBatchSize) {
+		search.check(batch)
+	}
+
+	var parameters []Parameter
+	for _, found := range search.found {
+		parameters = append(parameters, Parameter{
+			Name:   found.Name,
+			Reason: found.Reason,
+			Source: sources[found.Name],
+		})
+	}
+
+	slices.SortFunc(parameters, func(a Parameter, b Parameter) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return parameters
+}
+
+// Harvested names are tried before word list names, since they're specific to the host, so they're still tried
+// when the request budget runs out. Word list names keep the order of the word list
+func (finder *ParameterFinder) candidates(host string) ([]string, map[string]string) {
+	sources := make(map[string]string)
+
+	var names []string
+	for name, source := range finder.harvested[host] {
+		names = append(names, name)
+		sources[name] = source
+	}
+
+	slices.Sort(names)
+
+	for _, word := range finder.words {
+		if _, found := sources[word]; !found {
+			names = append(names, word)
+			sources[word] = WordlistSource
+		}
+	}
+
+	return names, sources
+}
+
+type parameterSearch struct {
+	finder   *ParameterFinder
+	target   *url.URL
+	baseline ResponseStats
+	budget   int
+	found    []Parameter
+
+	// Target reflects parameters it doesn't know about, so reflections don't tell hidden parameters apart
+	echoesQuery bool
+}
+
+// Requests the target with every name as a parameter, returning the unique value sent on each name
+func (search *parameterSearch) request(names []string) (ResponseStats, map[string]string, error) {
+	search.budget--
+
+	values := make(url.Values)
+	canaries := make(map[string]string)
+
+	for _, name := range names {
+		canary := "bh" + randomPath()[:8]
+		canaries[name] = canary
+		values.Set(name, canary)
+	}
+
+	variant := *search.target
+	variant.RawQuery = values.Encode()
+
+	stats, body, err := search.finder.prober.fetch(variant.String())
+
+	if err != nil {
+		return stats, nil, err
+	}
+
+	// Canaries are only kept when reflected, so callers can tell reflected names apart
+	for name, canary := range canaries {
+		if !strings.Contains(string(body), canary) {
+			delete(canaries, name)
+		}
+	}
+
+	return stats, canaries, nil
+}
+
+func (search *parameterSearch) check(names []string) {
+	if search.budget <= 0 || len(names) == 0 {
+		return
+	}
+
+	stats, reflected, err := search.request(names)
+
+	if err != nil {
+		return
+	}
+
+	if search.echoesQuery {
+		reflected = nil
+	}
+
+	var rest []string
+	for _, name := range names {
+		if _, found := reflected[name]; found {
+			search.found = append(search.found, Parameter{Name: name, Reason: ReflectedParameter})
+		} else {
+			rest = append(rest, name)
+		}
+	}
+
+	// Reflections change the response by themselves, so the other names are checked again without them
+	if len(rest) != len(names) {
+		search.check(rest)
+		return
+	}
+
+	if sameResponse(stats, search.baseline) {
+		return
+	}
+
+	if len(names) == 1 {
+		search.found = append(search.found, Parameter{Name: names[0], Reason: ChangedParameter})
+		return
+	}
+
+	search.check(names[:len(names)/2])
+	search.check(names[len(names)/2:])
+}
+
+// Size is left out, since dynamic pages often include values that change length between requests
+func sameResponse(a ResponseStats, b ResponseStats) bool {
+	return a.StatusCode == b.StatusCode && a.Words == b.Words && a.Lines == b.Lines
+}
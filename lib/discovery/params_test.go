@@ -0,0 +1,91 @@
+package discovery
+
+import (
+	"bloodhound/lib/client"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParameterFinder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		if query.Get("debug") != "" {
+			fmt.Fprintln(w, "<html><body><pre>Debug mode enabled\nDatabase: 10.0.0.5</pre></body></html>")
+			return
+		}
+
+		fmt.Fprintf(w, "<html><body><h1>Products</h1><a href=\"?lang=%s\">Back</a></body></html>\n", query.Get("callback_url"))
+	}))
+	defer server.Close()
+
+	limiter := client.NewRateLimiter(1000)
+	defer limiter.Stop()
+
+	finder := NewParameterFinder(client.NewClient(client.ClientConfig{}), limiter, []string{"id", "q", "debug", "page", "sort"})
+	finder.Harvest(server.URL+"/other", []string{"callback_url"}, ScriptSource)
+
+	parameters := finder.Find(server.URL + "/products")
+
+	expected := []Parameter{
+		{Name: "callback_url", Reason: ReflectedParameter, Source: ScriptSource},
+		{Name: "debug", Reason: ChangedParameter, Source: WordlistSource},
+	}
+
+	if len(expected) != len(parameters) {
+		t.Fatalf("Find; want %+v; got %+v", expected, parameters)
+	}
+
+	for i := range expected {
+		if expected[i] != parameters[i] {
+			t.Errorf("Find; want %+v; got %+v", expected[i], parameters[i])
+		}
+	}
+
+	t.Run("harvested names are tried first", func(t *testing.T) {
+		parsed, _ := url.Parse(server.URL)
+		names, _ := finder.candidates(parsed.Host)
+		expected := []string{"callback_url", "id", "q", "debug", "page", "sort"}
+
+		if !slices.Equal(expected, names) {
+			t.Errorf("candidates; want %v; got %v", expected, names)
+		}
+	})
+
+	t.Run("target echoing the query string", func(t *testing.T) {
+		echo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("debug") != "" {
+				fmt.Fprintln(w, "<html><body><pre>Debug mode enabled\nDatabase: 10.0.0.5</pre></body></html>")
+				return
+			}
+
+			fmt.Fprintf(w, "<html><head><link rel=\"canonical\" href=\"/products?%s\"></head><body><h1>Products</h1></body></html>\n", r.URL.RawQuery)
+		}))
+		defer echo.Close()
+
+		expected := []Parameter{{Name: "debug", Reason: ChangedParameter, Source: WordlistSource}}
+		parameters := finder.Find(echo.URL + "/products")
+
+		if len(parameters) != 1 || parameters[0] != expected[0] {
+			t.Errorf("Find; want %+v; got %+v", expected, parameters)
+		}
+	})
+
+	t.Run("unstable target", func(t *testing.T) {
+		count := 0
+		unstable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			count++
+			fmt.Fprint(w, strings.Repeat("word ", count))
+		}))
+		defer unstable.Close()
+
+		if parameters := finder.Find(unstable.URL); parameters != nil {
+			t.Errorf("Find; want no parameters on unstable target; got %+v", parameters)
+		}
+	})
+}
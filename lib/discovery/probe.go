@@ -0,0 +1,102 @@
+package discovery
+
+import (
+	"bloodhound/lib/client"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"net/http"
+)
+
+// Number of random paths requested to learn how a base responds to resources that don't exist
+const calibrationProbes = 3
+
+type ResponseStats struct {
+	StatusCode int
+	Size       int
+	Words      int
+	Lines      int
+}
+
+type prober struct {
+	client  *client.BloodhoundClient
+	limiter *client.RateLimiter
+}
+
+func newProber(client *client.BloodhoundClient, limiter *client.RateLimiter) *prober {
+	return &prober{
+		client:  client,
+		limiter: limiter,
+	}
+}
+
+func (prober *prober) probe(target string) (ResponseStats, error) {
+	stats, _, err := prober.fetch(target)
+	return stats, err
+}
+
+func (prober *prober) fetch(target string) (ResponseStats, []byte, error) {
+	prober.limiter.Wait()
+
+	request, err := http.NewRequest("GET", target, nil)
+
+	if err != nil {
+		return ResponseStats{}, nil, err
+	}
+
+	response, err := prober.client.DoWithoutRedirects(request)
+
+	if err != nil {
+		return ResponseStats{}, nil, err
+	}
+
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+
+	if err != nil {
+		return ResponseStats{}, nil, err
+	}
+
+	return NewResponseStats(response.StatusCode, body), body, nil
+}
+
+// Same measures used by ffuf to tell responses apart
+func NewResponseStats(statusCode int, body []byte) ResponseStats {
+	return ResponseStats{
+		StatusCode: statusCode,
+		Size:       len(body),
+		Words:      len(bytes.Fields(body)),
+		Lines:      bytes.Count(body, []byte("\n")) + 1,
+	}
+}
+
+// Requests random paths on a base, the responses are used as baselines of "not found" responses
+func (prober *prober) calibrate(base string) []ResponseStats {
+	var baselines []ResponseStats
+
+	for i := range calibrationProbes {
+		path := randomPath()
+
+		// Also calibrate with a file extension, since some servers route them differently
+		if i == calibrationProbes-1 {
+			path += ".html"
+		}
+
+		stats, err := prober.probe(base + path)
+
+		if err == nil {
+			baselines = append(baselines, stats)
+		}
+	}
+
+	return baselines
+}
+
+func randomPath() string {
+	data := make([]byte, 12)
+	rand.Read(data)
+
+	return hex.EncodeToString(data)
+}
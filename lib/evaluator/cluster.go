@@ -0,0 +1,110 @@
+package evaluator
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/simhash"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/html"
+)
+
+// Pages rendered from the same template share their structure, but their text changes on every page.
+// Unrelated texts differ on about half of the fingerprint bits, so a looser distance still tells them apart
+const similarTextDistance = 16
+
+type DocumentFingerprint struct {
+	// Every element contributes the path of tags and classes leading to it
+	Structure uint64
+	Text      uint64
+}
+
+func Fingerprint(document *html.Node) DocumentFingerprint {
+	var elements []string
+	var text strings.Builder
+
+	for node := range document.Descendants() {
+		switch node.Type {
+		case html.ElementNode:
+			elements = append(elements, elementPath(node))
+		case html.TextNode:
+			text.WriteString(node.Data)
+			text.WriteString(" ")
+		}
+	}
+
+	return DocumentFingerprint{
+		Structure: simhash.Simhash(elements),
+		Text:      simhash.Text([]byte(text.String())),
+	}
+}
+
+func (fingerprint DocumentFingerprint) Similar(other DocumentFingerprint) bool {
+	return simhash.Similar(fingerprint.Structure, other.Structure) &&
+		simhash.Distance(fingerprint.Text, other.Text) <= similarTextDistance
+}
+
+func elementPath(node *html.Node) string {
+	var path []string
+
+	for ; node != nil && node.Type == html.ElementNode; node = node.Parent {
+		element := node.Data
+		if class := getAttrMap(node.Attr)["class"]; class != "" {
+			element += "." + strings.Join(strings.Fields(class), ".")
+		}
+
+		path = append([]string{element}, path...)
+	}
+
+	return strings.Join(path, ">")
+}
+
+// Groups near-duplicate targets, keeping the first target of each cluster as its representative.
+// Since results are sorted by score, the representative is the highest ranked member, and the other
+// members are only listed on it. Targets without content are never clustered
+func Cluster(results []pipeline.Context) []pipeline.Context {
+	var clustered []pipeline.Context
+	var fingerprints []DocumentFingerprint
+
+	representatives := make(map[int]int)
+
+	for _, context := range results {
+		if context.Content == nil {
+			clustered = append(clustered, context)
+			continue
+		}
+
+		fingerprint := Fingerprint(context.Content)
+		found := false
+
+		for i, representative := range fingerprints {
+			if !fingerprint.Similar(representative) {
+				continue
+			}
+
+			index := representatives[i]
+			clustered[index].Duplicates = append(clustered[index].Duplicates, context.Url)
+			found = true
+
+			log.WithFields(log.Fields{
+				"target":         context.Url,
+				"representative": clustered[index].Url,
+			}).Trace("Target is a near-duplicate")
+
+			break
+		}
+
+		if !found {
+			representatives[len(fingerprints)] = len(clustered)
+			fingerprints = append(fingerprints, fingerprint)
+			clustered = append(clustered, context)
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"targets":  len(results),
+		"clusters": len(clustered),
+	}).Info("Finished clustering near-duplicate targets")
+
+	return clustered
+}
@@ -0,0 +1,74 @@
+package evaluator
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"fmt"
+	"testing"
+)
+
+func TestCluster(t *testing.T) {
+	product := func(name string) string {
+		return fmt.Sprintf(`<html>
+			<head><title>%s - Shop</title></head>
+			<body>
+				<nav class="menu"><a href="/">Home</a><a href="/products">Products</a><a href="/cart">Cart</a></nav>
+				<div class="product">
+					<h1>%s</h1>
+					<p class="price">Free shipping on orders over 50, returns accepted within 30 days of delivery</p>
+					<form action="/cart" method="post"><input type="hidden" name="product" value="%s"><button>Add to cart</button></form>
+				</div>
+				<footer class="footer">Copyright Shop, all rights reserved. Terms of service and privacy policy</footer>
+			</body>
+			</html>`, name, name, name)
+	}
+
+	login := `<html>
+		<head><title>Sign in</title></head>
+		<body>
+			<form action="/login" method="post">
+				<label>Username</label><input type="text" name="username">
+				<label>Password</label><input type="password" name="password">
+				<input type="hidden" name="csrf" value="token">
+				<button type="submit">Sign in</button>
+			</form>
+			<script src="/static/login.js"></script>
+		</body>
+		</html>`
+
+	newContext := func(url string, page string) pipeline.Context {
+		context := pipeline.NewContext(url)
+		context.Content = getHTMLDocument(page)
+		return context
+	}
+
+	results := []pipeline.Context{
+		newContext("http://localhost/products/1", product("Blue mug")),
+		newContext("http://localhost/login", login),
+		newContext("http://localhost/products/2", product("Red mug")),
+		pipeline.NewContext("http://localhost/api"),
+		newContext("http://localhost/products/3", product("Green mug")),
+	}
+
+	clustered := Cluster(results)
+
+	expected := []string{"http://localhost/products/1", "http://localhost/login", "http://localhost/api"}
+
+	if len(expected) != len(clustered) {
+		t.Fatalf("Cluster; want %v targets; got %v", len(expected), len(clustered))
+	}
+
+	for i := range expected {
+		if expected[i] != clustered[i].Url {
+			t.Errorf("Cluster; want %v; got %v", expected[i], clustered[i].Url)
+		}
+	}
+
+	duplicates := clustered[0].Duplicates
+	if len(duplicates) != 2 || duplicates[0] != "http://localhost/products/2" || duplicates[1] != "http://localhost/products/3" {
+		t.Errorf("Cluster; want products 2 and 3 as duplicates; got %v", duplicates)
+	}
+
+	if len(clustered[1].Duplicates) != 0 {
+		t.Errorf("Cluster; want no duplicates; got %v", clustered[1].Duplicates)
+	}
+}
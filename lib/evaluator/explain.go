@@ -0,0 +1,375 @@
+package evaluator
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/rules"
+	"bloodhound/lib/scoring"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+type RuleExplanation struct {
+	Rule    rules.Rule
+	Matched bool
+
+	// Rule matched and would remove the target from the results
+	Removed bool
+
+	Match pipeline.Match
+
+	// Line of the matched node on the response body, 0 when unknown or not applicable
+	Line int
+
+	// Score added by the rule, compared to the same target without it
+	Points float64
+
+	// Why the rule didn't match
+	Reason string
+}
+
+type Explanation struct {
+	Context pipeline.Context
+	Removed bool
+	Rules   []RuleExplanation
+}
+
+// Evaluates every rule individually against an already retrieved context, keeping track of why each rule matched or not
+func Explain(context pipeline.Context, ruleset *rules.Ruleset) Explanation {
+	explanation := Explanation{
+		Context: context,
+	}
+
+	// Explained contexts don't go through the pipeline, so facts rules match on are detected here
+	context.DetectTechnologies()
+	context.DetectSecrets()
+	context.Forms = AnalyzeForms(context.Content, context.Url)
+	explanation.Context = context
+
+	var source *sourceIndex
+	if context.Response != nil {
+		source = newSourceIndex(context.Content, context.Response.Body)
+	}
+
+	for _, rule := range ruleset.Rules {
+		ruleList := []rules.Rule{rule}
+		result := RuleExplanation{Rule: rule}
+
+		var evaluation EvaluationResult
+
+		switch rule.Level {
+		case rules.ResourceLevel:
+			evaluation = EvaluateUrl(&context.Url, &ruleList)
+			result.Reason = fmt.Sprintf("none of %s found in URL", quoteAll(rule.Content.Matches))
+		case rules.ContentLevel:
+			evaluation = EvaluateHTML(context.Content, ruleList)
+			result.Reason = explainContentMiss(context, &rule)
+		case rules.TechLevel:
+			evaluation = EvaluateTechnologies(context.Technologies, ruleList)
+			result.Reason = explainTechMiss(context, &rule)
+		case rules.FindingLevel:
+			evaluation = EvaluateFindings(context.Findings, ruleList)
+			result.Reason = explainFindingMiss(context, &rule)
+		case rules.FormLevel:
+			evaluation = EvaluateForms(context.Forms, ruleList)
+			result.Reason = explainFormMiss(context, &rule)
+		case rules.ReflectionLevel:
+			evaluation = EvaluateReflections(context.Reflections, ruleList)
+			result.Reason = explainReflectionMiss(context, &rule)
+		case rules.ParameterLevel:
+			evaluation = EvaluateParameters(context.Parameters, ruleList)
+			result.Reason = explainParameterMiss(context, &rule)
+		case rules.GraphqlLevel:
+			evaluation = EvaluateGraphql(context.Graphql, ruleList)
+			result.Reason = explainGraphqlMiss(context, &rule)
+		case rules.JsonLevel:
+			evaluation = EvaluateJson(context.Json, ruleList)
+			result.Reason = explainJsonMiss(context, &rule)
+		case rules.ProvenanceLevel:
+			evaluation = EvaluateProvenance(context.Sources, ruleList)
+			result.Reason = explainProvenanceMiss(context, &rule)
+		}
+
+		if evaluation.Remove {
+			result.Matched = true
+			result.Removed = true
+			explanation.Removed = true
+		}
+
+		if len(evaluation.Matches) != 0 {
+			result.Matched = true
+			result.Match = evaluation.Matches[0]
+
+			if source != nil {
+				result.Line = source.Line(result.Match.Node)
+			}
+		}
+
+		if result.Matched {
+			result.Reason = ""
+		}
+
+		explanation.Rules = append(explanation.Rules, result)
+	}
+
+	explanation.score(scoring.NewModel(ruleset.Scoring, ruleset.Rules))
+
+	return explanation
+}
+
+func (explanation *Explanation) score(model scoring.Model) {
+	var matched []pipeline.Match
+	for _, result := range explanation.Rules {
+		if result.Matched && !result.Removed {
+			matched = append(matched, result.Match)
+		}
+	}
+
+	explanation.Context.Matches = matched
+
+	if explanation.Removed {
+		explanation.Context.Score = 0
+		return
+	}
+
+	explanation.Context.Score = model.Score(explanation.Context.MatchedRules())
+
+	for i, result := range explanation.Rules {
+		if !result.Matched {
+			continue
+		}
+
+		var others []rules.Rule
+		for _, match := range matched {
+			if match.Rule.Name != result.Rule.Name {
+				others = append(others, match.Rule)
+			}
+		}
+
+		explanation.Rules[i].Points = explanation.Context.Score - model.Score(others)
+	}
+}
+
+func explainContentMiss(context pipeline.Context, rule *rules.Rule) string {
+	if context.Content == nil {
+		if context.Response == nil {
+			return "content not available, resource was not retrieved"
+		}
+
+		return fmt.Sprintf("content not available, resource returned HTTP %d", context.Response.StatusCode)
+	}
+
+	if rule.Content.Element == "" {
+		return fmt.Sprintf("no text contains any of %s", quoteAll(rule.Content.Matches))
+	}
+
+	var elements []*html.Node
+	for node := range context.Content.Descendants() {
+		if node.Type == html.ElementNode && node.Data == rule.Content.Element {
+			elements = append(elements, node)
+		}
+	}
+
+	if len(elements) == 0 {
+		return fmt.Sprintf("no <%s> element found", rule.Content.Element)
+	}
+
+	// Elements were found, so attributes are the reason of the mismatch
+	var expected []string
+	var seen []string
+
+	for key, value := range rule.Content.Attr {
+		expected = append(expected, fmt.Sprintf("%s=%q", key, value))
+
+		for _, element := range elements {
+			if actual, hasKey := getAttrMap(element.Attr)[key]; hasKey {
+				seen = append(seen, fmt.Sprintf("%s=%q", key, actual))
+			}
+		}
+	}
+
+	slices.Sort(expected)
+	slices.Sort(seen)
+
+	return fmt.Sprintf("found %d <%s> elements, none with %s (seen %s)",
+		len(elements), rule.Content.Element, strings.Join(expected, " "), strings.Join(slices.Compact(seen), ", "))
+}
+
+func explainTechMiss(context pipeline.Context, rule *rules.Rule) string {
+	if context.Response == nil {
+		return "technologies not available, resource was not retrieved"
+	}
+
+	technology, found := findTechnology(context.Technologies, rule.Content.Technology)
+
+	if !found {
+		return fmt.Sprintf("%s not detected", rule.Content.Technology)
+	}
+
+	if technology.Version == "" {
+		return fmt.Sprintf("%s detected, but its version is not visible (expected %s)", technology.Name, rule.Content.Version)
+	}
+
+	return fmt.Sprintf("%s detected, doesn't satisfy %s", technology.String(), rule.Content.Version)
+}
+
+func explainFindingMiss(context pipeline.Context, rule *rules.Rule) string {
+	if context.Response == nil {
+		return "findings not available, resource was not retrieved"
+	}
+
+	if len(context.Findings) == 0 {
+		return "no secrets found"
+	}
+
+	return fmt.Sprintf("no %s found, %d other secrets found", rule.Content.Finding, len(context.Findings))
+}
+
+func explainFormMiss(context pipeline.Context, rule *rules.Rule) string {
+	if context.Content == nil {
+		return "forms not available, content was not retrieved"
+	}
+
+	if len(context.Forms) == 0 {
+		return "no form found"
+	}
+
+	condition := rule.Content.Form
+
+	var expected []string
+	if condition.Method != "" {
+		expected = append(expected, "method "+strings.ToUpper(condition.Method))
+	}
+	if condition.Enctype != "" {
+		expected = append(expected, "enctype "+condition.Enctype)
+	}
+	if condition.Origin != "" {
+		expected = append(expected, condition.Origin+" origin action")
+	}
+	if condition.Csrf != nil {
+		if *condition.Csrf {
+			expected = append(expected, "CSRF token")
+		} else {
+			expected = append(expected, "no CSRF token")
+		}
+	}
+	if len(condition.Fields) != 0 {
+		expected = append(expected, strings.Join(condition.Fields, ", ")+" fields")
+	}
+
+	return fmt.Sprintf("found %d forms, none with %s", len(context.Forms), strings.Join(expected, " and "))
+}
+
+func explainReflectionMiss(context pipeline.Context, rule *rules.Rule) string {
+	if len(context.Reflections) == 0 {
+		return "no parameter reflected, or reflections were not probed (--probe-reflections)"
+	}
+
+	condition := rule.Content.Reflection
+
+	var expected []string
+	if condition.Context != "" {
+		expected = append(expected, condition.Context+" context")
+	}
+	if condition.Unescaped != nil {
+		if *condition.Unescaped {
+			expected = append(expected, "unescaped special characters")
+		} else {
+			expected = append(expected, "escaped special characters")
+		}
+	}
+
+	return fmt.Sprintf("found %d reflections, none with %s", len(context.Reflections), strings.Join(expected, " and "))
+}
+
+func explainParameterMiss(context pipeline.Context, rule *rules.Rule) string {
+	if len(context.Parameters) == 0 {
+		return "no hidden parameter found, or parameters were not discovered (--discover-params)"
+	}
+
+	condition := rule.Content.Parameter
+
+	var expected []string
+	if len(condition.Names) != 0 {
+		expected = append(expected, "name containing any of "+quoteAll(condition.Names))
+	}
+	if condition.Reason != "" {
+		expected = append(expected, "reason "+condition.Reason)
+	}
+	if condition.Source != "" {
+		expected = append(expected, "source "+condition.Source)
+	}
+
+	return fmt.Sprintf("found %d parameters, none with %s", len(context.Parameters), strings.Join(expected, " and "))
+}
+
+func explainGraphqlMiss(context pipeline.Context, rule *rules.Rule) string {
+	if context.Graphql == nil {
+		return "not a confirmed GraphQL endpoint, or endpoints were not probed (--probe-graphql)"
+	}
+
+	condition := rule.Content.Graphql
+
+	var expected []string
+	if condition.Introspection != nil {
+		if *condition.Introspection {
+			expected = append(expected, "introspection enabled")
+		} else {
+			expected = append(expected, "introspection disabled")
+		}
+	}
+	if condition.Mutations > 0 {
+		expected = append(expected, fmt.Sprintf("at least %d mutations (has %d)", condition.Mutations, context.Graphql.Mutations))
+	}
+	if len(condition.Fields) != 0 {
+		expected = append(expected, "field containing any of "+quoteAll(condition.Fields))
+	}
+
+	return "endpoint doesn't have " + strings.Join(expected, " and ")
+}
+
+func explainJsonMiss(context pipeline.Context, rule *rules.Rule) string {
+	if context.Json == nil {
+		return "response is not JSON"
+	}
+
+	condition := rule.Content.Json
+
+	var expected []string
+	if condition.Path != "" {
+		expected = append(expected, "node at "+condition.Path)
+	}
+	if condition.Equals != nil {
+		expected = append(expected, "value "+*condition.Equals)
+	}
+	if len(condition.Keys) != 0 {
+		expected = append(expected, "key containing any of "+quoteAll(condition.Keys))
+	}
+
+	return "no " + strings.Join(expected, " with ") + " found"
+}
+
+func explainProvenanceMiss(context pipeline.Context, rule *rules.Rule) string {
+	if len(context.Sources) == 0 {
+		return "source of the target is unknown"
+	}
+
+	kinds := strings.Join(slices.Compact(context.SourceKinds()), ", ")
+
+	if rule.Content.Provenance.Only {
+		return fmt.Sprintf("target was found on %s, not only on %s", kinds, quoteAll(rule.Content.Provenance.Sources))
+	}
+
+	return fmt.Sprintf("target was found on %s, not on any of %s", kinds, quoteAll(rule.Content.Provenance.Sources))
+}
+
+func quoteAll(words []string) string {
+	var quoted []string
+	for _, word := range words {
+		quoted = append(quoted, fmt.Sprintf("%q", word))
+	}
+
+	return strings.Join(quoted, ", ")
+}
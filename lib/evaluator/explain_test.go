@@ -0,0 +1,90 @@
+package evaluator
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/rules"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	page := `<!DOCTYPE html>
+<html>
+<body>
+	<form action="/login">
+		<input type="email" name="login">
+		<input type="password" name="password">
+	</form>
+	<p>Forgot your password?</p>
+</body>
+</html>`
+
+	ruleset := &rules.Ruleset{
+		Rules: []rules.Rule{
+			rules.NewResourceRule("Is login", 1, false, rules.NewMatchRuleContent([]string{"login"})),
+			rules.NewResourceRule("Is search", 1, false, rules.NewMatchRuleContent([]string{"search", "query"})),
+			rules.NewContentRule("Has password input", 2, false, rules.NewElementRuleContent("input", map[string]string{"type": "password"})),
+			rules.NewContentRule("Has file input", 4, false, rules.NewElementRuleContent("input", map[string]string{"type": "file"})),
+			rules.NewContentRule("Has table", 1, false, rules.NewElementRuleContent("table", nil)),
+			rules.NewContentRule("Mentions password", 1, false, rules.NewMatchRuleContent([]string{"Forgot"})),
+		},
+	}
+
+	context := pipeline.NewContext("http://localhost/login")
+	context.Content = getHTMLDocument(page)
+	context.Response = &pipeline.Response{StatusCode: 200, Body: []byte(page)}
+
+	explanation := Explain(context, ruleset)
+
+	assert := func(t *testing.T, index int, expected RuleExplanation) {
+		actual := explanation.Rules[index]
+
+		if expected.Matched != actual.Matched || expected.Points != actual.Points || expected.Line != actual.Line || expected.Reason != actual.Reason {
+			t.Errorf("Explain; want %+v; got %+v", expected, actual)
+		}
+	}
+
+	t.Run("total score", func(t *testing.T) {
+		if explanation.Context.Score != 4 || explanation.Removed {
+			t.Errorf("Explain; want score 4; got %v", explanation.Context.Score)
+		}
+	})
+
+	t.Run("matched resource rule", func(t *testing.T) {
+		assert(t, 0, RuleExplanation{Matched: true, Points: 1})
+
+		if explanation.Rules[0].Match.Location != "URL path" {
+			t.Errorf("Explain; want match on URL path; got %q", explanation.Rules[0].Match.Location)
+		}
+	})
+
+	t.Run("missed resource rule", func(t *testing.T) {
+		assert(t, 1, RuleExplanation{Reason: `none of "search", "query" found in URL`})
+	})
+
+	t.Run("matched element with line", func(t *testing.T) {
+		assert(t, 2, RuleExplanation{Matched: true, Points: 2, Line: 6})
+	})
+
+	t.Run("element with different attributes", func(t *testing.T) {
+		assert(t, 3, RuleExplanation{Reason: `found 2 <input> elements, none with type="file" (seen type="email", type="password")`})
+	})
+
+	t.Run("missing element", func(t *testing.T) {
+		assert(t, 4, RuleExplanation{Reason: "no <table> element found"})
+	})
+
+	t.Run("matched text with line", func(t *testing.T) {
+		assert(t, 5, RuleExplanation{Matched: true, Points: 1, Line: 8})
+	})
+
+	t.Run("content not available", func(t *testing.T) {
+		context := pipeline.NewContext("http://localhost/login")
+		context.Response = &pipeline.Response{StatusCode: 404}
+
+		explanation := Explain(context, ruleset)
+
+		if explanation.Rules[2].Reason != "content not available, resource returned HTTP 404" {
+			t.Errorf("Explain; want missing content reason; got %q", explanation.Rules[2].Reason)
+		}
+	})
+}
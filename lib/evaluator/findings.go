@@ -0,0 +1,44 @@
+package evaluator
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/rules"
+	"bloodhound/lib/secrets"
+)
+
+// Matches any finding type
+const AnyFinding = "*"
+
+func EvaluateFindings(findings []secrets.Finding, ruleList []rules.Rule) EvaluationResult {
+	result := DefaultEvaluationResult()
+
+	for _, rule := range ruleList {
+		if rule.Level != rules.FindingLevel {
+			continue
+		}
+
+		finding, found := findFinding(findings, rule.Content.Finding)
+
+		if !found {
+			continue
+		}
+
+		if rule.Remove {
+			return NewEvaluationResult(0, rule.Remove)
+		}
+
+		result.addMatch(pipeline.NewMatch(rule, finding.Location, finding.Type+" "+finding.Preview))
+	}
+
+	return result
+}
+
+func findFinding(findings []secrets.Finding, findingType string) (secrets.Finding, bool) {
+	for _, finding := range findings {
+		if findingType == AnyFinding || finding.Type == findingType {
+			return finding, true
+		}
+	}
+
+	return secrets.Finding{}, false
+}
@@ -0,0 +1,40 @@
+package evaluator
+
+import (
+	"bloodhound/lib/rules"
+	"bloodhound/lib/secrets"
+	"testing"
+)
+
+func TestEvaluateFindings(t *testing.T) {
+	findings := []secrets.Finding{
+		{Type: secrets.AwsAccessKey, Location: "comment", Preview: "AKIA**************LE"},
+	}
+
+	assert := func(t testing.TB, expected EvaluationResult, actual EvaluationResult) {
+		t.Helper()
+		if expected.Score != actual.Score || expected.Remove != actual.Remove {
+			t.Errorf("EvaluateFindings; want %v; got %v", expected, actual)
+		}
+	}
+
+	newRule := func(finding string, value float64) rules.Rule {
+		return rules.NewRule("Leaks "+finding, rules.FindingLevel, value, false, rules.RuleContent{Finding: finding})
+	}
+
+	t.Run("finding of rule type", func(t *testing.T) {
+		assert(t, NewEvaluationResult(5, false), EvaluateFindings(findings, []rules.Rule{newRule(secrets.AwsAccessKey, 5)}))
+	})
+
+	t.Run("finding of other type", func(t *testing.T) {
+		assert(t, DefaultEvaluationResult(), EvaluateFindings(findings, []rules.Rule{newRule(secrets.PrivateKey, 8)}))
+	})
+
+	t.Run("any finding", func(t *testing.T) {
+		assert(t, NewEvaluationResult(1, false), EvaluateFindings(findings, []rules.Rule{newRule(AnyFinding, 1)}))
+	})
+
+	t.Run("no findings", func(t *testing.T) {
+		assert(t, DefaultEvaluationResult(), EvaluateFindings(nil, []rules.Rule{newRule(AnyFinding, 1)}))
+	})
+}
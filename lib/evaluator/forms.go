@@ -0,0 +1,208 @@
+package evaluator
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/rules"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Hidden field names commonly used by frameworks for anti-CSRF tokens
+var csrfFieldName = regexp.MustCompile(`(?i)csrf|xsrf|authenticity_token|requestverificationtoken|^_?token$|nonce`)
+
+// Input types that only submit or reset the form, and don't carry user input
+var buttonTypes = []string{"submit", "button", "reset", "image"}
+
+// Groups each form of a document with its fields, fields outside a form are grouped by their form attribute
+func AnalyzeForms(document *html.Node, pageUrl string) []pipeline.Form {
+	page, err := url.Parse(pageUrl)
+
+	if err != nil || document == nil {
+		return nil
+	}
+
+	var forms []pipeline.Form
+	formNodes := make(map[*html.Node]int)
+	formIds := make(map[string]int)
+
+	var fieldNodes []*html.Node
+
+	for node := range document.Descendants() {
+		if node.Type != html.ElementNode {
+			continue
+		}
+
+		switch node.Data {
+		case "form":
+			attrs := getAttrMap(node.Attr)
+
+			if id := attrs["id"]; id != "" {
+				formIds[id] = len(forms)
+			}
+
+			formNodes[node] = len(forms)
+			forms = append(forms, newForm(page, attrs))
+		case "input", "select", "textarea":
+			fieldNodes = append(fieldNodes, node)
+		}
+	}
+
+	for _, node := range fieldNodes {
+		if index, found := fieldForm(node, formNodes, formIds); found {
+			addField(&forms[index], node)
+		}
+	}
+
+	return forms
+}
+
+func newForm(page *url.URL, attrs map[string]string) pipeline.Form {
+	form := pipeline.Form{
+		Action:  page.String(),
+		Method:  "GET",
+		Enctype: "application/x-www-form-urlencoded",
+	}
+
+	if action := strings.TrimSpace(attrs["action"]); action != "" {
+		if resolved, err := page.Parse(action); err == nil {
+			form.Action = resolved.String()
+			form.CrossOrigin = resolved.Scheme != page.Scheme || resolved.Host != page.Host
+		}
+	}
+
+	if method := strings.ToUpper(strings.TrimSpace(attrs["method"])); method != "" {
+		form.Method = method
+	}
+
+	if enctype := strings.ToLower(strings.TrimSpace(attrs["enctype"])); enctype != "" {
+		form.Enctype = enctype
+	}
+
+	return form
+}
+
+// Form a field submits with, either referenced by its form attribute or its closest form ancestor
+func fieldForm(node *html.Node, formNodes map[*html.Node]int, formIds map[string]int) (int, bool) {
+	if id := getAttrMap(node.Attr)["form"]; id != "" {
+		index, found := formIds[id]
+		return index, found
+	}
+
+	for parent := range node.Ancestors() {
+		if index, found := formNodes[parent]; found {
+			return index, true
+		}
+	}
+
+	return 0, false
+}
+
+func addField(form *pipeline.Form, node *html.Node) {
+	attrs := getAttrMap(node.Attr)
+
+	fieldType := node.Data
+	if node.Data == "input" {
+		fieldType = strings.ToLower(strings.TrimSpace(attrs["type"]))
+
+		if fieldType == "" {
+			fieldType = "text"
+		}
+	}
+
+	if slices.Contains(buttonTypes, fieldType) {
+		return
+	}
+
+	form.Fields = append(form.Fields, pipeline.FormField{
+		Name: attrs["name"],
+		Type: fieldType,
+	})
+
+	switch fieldType {
+	case "hidden":
+		if csrfFieldName.MatchString(attrs["name"]) {
+			form.HasCsrfToken = true
+		}
+	case "file":
+		form.HasFileField = true
+	case "password":
+		form.HasPasswordField = true
+	case "email":
+		form.HasEmailField = true
+	}
+}
+
+func EvaluateForms(forms []pipeline.Form, ruleList []rules.Rule) EvaluationResult {
+	result := DefaultEvaluationResult()
+
+	for _, rule := range ruleList {
+		if rule.Level != rules.FormLevel || rule.Content.Form == nil {
+			continue
+		}
+
+		for _, form := range forms {
+			if !formMatchesCondition(&form, rule.Content.Form) {
+				continue
+			}
+
+			if rule.Remove {
+				return NewEvaluationResult(0, rule.Remove)
+			}
+
+			result.addMatch(pipeline.NewMatch(rule, "form", describeForm(&form)))
+
+			break
+		}
+	}
+
+	return result
+}
+
+func formMatchesCondition(form *pipeline.Form, condition *rules.FormCondition) bool {
+	if condition.Method != "" && !strings.EqualFold(condition.Method, form.Method) {
+		return false
+	}
+
+	if condition.Enctype != "" && !strings.EqualFold(condition.Enctype, form.Enctype) {
+		return false
+	}
+
+	switch condition.Origin {
+	case rules.SameOrigin:
+		if form.CrossOrigin {
+			return false
+		}
+	case rules.CrossOrigin:
+		if !form.CrossOrigin {
+			return false
+		}
+	}
+
+	if condition.Csrf != nil && *condition.Csrf != form.HasCsrfToken {
+		return false
+	}
+
+	for _, fieldType := range condition.Fields {
+		if !form.HasFieldType(strings.ToLower(fieldType)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func describeForm(form *pipeline.Form) string {
+	var types []string
+	for _, field := range form.Fields {
+		if !slices.Contains(types, field.Type) {
+			types = append(types, field.Type)
+		}
+	}
+
+	return fmt.Sprintf("%s %s (%s) fields: %s", form.Method, form.Action, form.Enctype, strings.Join(types, ", "))
+}
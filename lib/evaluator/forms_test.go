@@ -0,0 +1,127 @@
+package evaluator
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/rules"
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeForms(t *testing.T) {
+	page := `<html>
+		<body>
+			<form id="login" action="/session" method="post">
+				<input type="email" name="email">
+				<input type="password" name="password">
+				<input type="hidden" name="authenticity_token" value="abc">
+				<button type="submit">Sign in</button>
+			</form>
+			<form action="https://files.localhost/upload" method="POST" enctype="multipart/form-data">
+				<input type="file" name="document">
+				<textarea name="comment"></textarea>
+				<input type="submit" value="Upload">
+			</form>
+			<form>
+				<input name="q">
+			</form>
+			<input type="checkbox" name="remember" form="login">
+		</body>
+		</html>`
+
+	forms := AnalyzeForms(getHTMLDocument(page), "http://localhost/account")
+
+	expected := []pipeline.Form{
+		{
+			Action:  "http://localhost/session",
+			Method:  "POST",
+			Enctype: "application/x-www-form-urlencoded",
+			Fields: []pipeline.FormField{
+				{Name: "email", Type: "email"},
+				{Name: "password", Type: "password"},
+				{Name: "authenticity_token", Type: "hidden"},
+				{Name: "remember", Type: "checkbox"},
+			},
+			HasCsrfToken:     true,
+			HasPasswordField: true,
+			HasEmailField:    true,
+		},
+		{
+			Action:      "https://files.localhost/upload",
+			Method:      "POST",
+			Enctype:     "multipart/form-data",
+			CrossOrigin: true,
+			Fields: []pipeline.FormField{
+				{Name: "document", Type: "file"},
+				{Name: "comment", Type: "textarea"},
+			},
+			HasFileField: true,
+		},
+		{
+			Action:  "http://localhost/account",
+			Method:  "GET",
+			Enctype: "application/x-www-form-urlencoded",
+			Fields:  []pipeline.FormField{{Name: "q", Type: "text"}},
+		},
+	}
+
+	if len(expected) != len(forms) {
+		t.Fatalf("AnalyzeForms; want %+v; got %+v", expected, forms)
+	}
+
+	for i := range expected {
+		if !reflect.DeepEqual(expected[i], forms[i]) {
+			t.Errorf("AnalyzeForms; want %+v; got %+v", expected[i], forms[i])
+		}
+	}
+}
+
+func TestEvaluateForms(t *testing.T) {
+	page := `<html>
+		<body>
+			<form action="/profile" method="post"><input name="name"></form>
+			<form action="https://files.localhost/upload" method="post" enctype="multipart/form-data">
+				<input type="file" name="document">
+				<input type="hidden" name="csrf_token">
+			</form>
+		</body>
+		</html>`
+
+	forms := AnalyzeForms(getHTMLDocument(page), "http://localhost/")
+
+	assert := func(t testing.TB, expected EvaluationResult, actual EvaluationResult) {
+		t.Helper()
+		if expected.Score != actual.Score || expected.Remove != actual.Remove {
+			t.Errorf("EvaluateForms; want %v; got %v", expected, actual)
+		}
+	}
+
+	newRule := func(condition rules.FormCondition) rules.Rule {
+		return rules.NewRule("Form rule", rules.FormLevel, 2, false, rules.RuleContent{Form: &condition})
+	}
+
+	noCsrf := false
+
+	t.Run("post form without CSRF token", func(t *testing.T) {
+		result := EvaluateForms(forms, []rules.Rule{newRule(rules.FormCondition{Method: "post", Csrf: &noCsrf})})
+		assert(t, NewEvaluationResult(2, false), result)
+	})
+
+	t.Run("multipart upload to another origin", func(t *testing.T) {
+		result := EvaluateForms(forms, []rules.Rule{newRule(rules.FormCondition{
+			Enctype: "multipart/form-data",
+			Origin:  rules.CrossOrigin,
+			Fields:  []string{"file"},
+		})})
+		assert(t, NewEvaluationResult(2, false), result)
+	})
+
+	t.Run("conditions must hold on a single form", func(t *testing.T) {
+		result := EvaluateForms(forms, []rules.Rule{newRule(rules.FormCondition{Origin: rules.SameOrigin, Fields: []string{"file"}})})
+		assert(t, DefaultEvaluationResult(), result)
+	})
+
+	t.Run("page without forms", func(t *testing.T) {
+		result := EvaluateForms(nil, []rules.Rule{newRule(rules.FormCondition{})})
+		assert(t, DefaultEvaluationResult(), result)
+	})
+}
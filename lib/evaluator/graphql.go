@@ -0,0 +1,77 @@
+package evaluator
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/graphql"
+	"bloodhound/lib/rules"
+	"bloodhound/lib/utils"
+	"fmt"
+	"strings"
+)
+
+func EvaluateGraphql(summary *graphql.Summary, ruleList []rules.Rule) EvaluationResult {
+	result := DefaultEvaluationResult()
+
+	if summary == nil || !summary.Confirmed {
+		return result
+	}
+
+	for _, rule := range ruleList {
+		if rule.Level != rules.GraphqlLevel || rule.Content.Graphql == nil {
+			continue
+		}
+
+		if !graphqlMatchesCondition(summary, rule.Content.Graphql) {
+			continue
+		}
+
+		if rule.Remove {
+			return NewEvaluationResult(0, rule.Remove)
+		}
+
+		result.addMatch(pipeline.NewMatch(rule, "GraphQL endpoint", describeGraphql(summary)))
+	}
+
+	return result
+}
+
+func graphqlMatchesCondition(summary *graphql.Summary, condition *rules.GraphqlCondition) bool {
+	if condition.Introspection != nil && *condition.Introspection != summary.Introspection {
+		return false
+	}
+
+	if summary.Mutations < condition.Mutations {
+		return false
+	}
+
+	if len(condition.Fields) != 0 {
+		found := false
+
+		for _, field := range summary.Fields {
+			if utils.ContainsAnyFold(field, condition.Fields) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func describeGraphql(summary *graphql.Summary) string {
+	if !summary.Introspection {
+		return "introspection disabled"
+	}
+
+	description := fmt.Sprintf("introspection enabled, %d queries, %d mutations, %d types", summary.Queries, summary.Mutations, summary.Types)
+
+	if len(summary.SensitiveFields) != 0 {
+		description += ", sensitive fields: " + strings.Join(summary.SensitiveFields, ", ")
+	}
+
+	return description
+}
@@ -0,0 +1,58 @@
+package evaluator
+
+import (
+	"bloodhound/lib/graphql"
+	"bloodhound/lib/rules"
+	"testing"
+)
+
+func TestEvaluateGraphql(t *testing.T) {
+	summary := &graphql.Summary{
+		Confirmed:       true,
+		Introspection:   true,
+		Queries:         4,
+		Mutations:       2,
+		Types:           12,
+		Fields:          []string{"Query.users", "User.email", "User.passwordHash"},
+		SensitiveFields: []string{"User.passwordHash"},
+	}
+
+	assert := func(t testing.TB, expected EvaluationResult, actual EvaluationResult) {
+		t.Helper()
+		if expected.Score != actual.Score || expected.Remove != actual.Remove {
+			t.Errorf("EvaluateGraphql; want %v; got %v", expected, actual)
+		}
+	}
+
+	newRule := func(condition rules.GraphqlCondition, value float64) rules.Rule {
+		return rules.NewRule("GraphQL rule", rules.GraphqlLevel, value, false, rules.RuleContent{Graphql: &condition})
+	}
+
+	enabled := true
+	disabled := false
+
+	t.Run("any confirmed endpoint", func(t *testing.T) {
+		assert(t, NewEvaluationResult(1, false), EvaluateGraphql(summary, []rules.Rule{newRule(rules.GraphqlCondition{}, 1)}))
+	})
+
+	t.Run("not probed", func(t *testing.T) {
+		assert(t, DefaultEvaluationResult(), EvaluateGraphql(nil, []rules.Rule{newRule(rules.GraphqlCondition{}, 1)}))
+	})
+
+	t.Run("introspection", func(t *testing.T) {
+		assert(t, NewEvaluationResult(6, false), EvaluateGraphql(summary, []rules.Rule{newRule(rules.GraphqlCondition{Introspection: &enabled}, 6)}))
+		assert(t, DefaultEvaluationResult(), EvaluateGraphql(summary, []rules.Rule{newRule(rules.GraphqlCondition{Introspection: &disabled}, 6)}))
+	})
+
+	t.Run("minimum mutations", func(t *testing.T) {
+		assert(t, NewEvaluationResult(3, false), EvaluateGraphql(summary, []rules.Rule{newRule(rules.GraphqlCondition{Mutations: 2}, 3)}))
+		assert(t, DefaultEvaluationResult(), EvaluateGraphql(summary, []rules.Rule{newRule(rules.GraphqlCondition{Mutations: 3}, 3)}))
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		assert(t, NewEvaluationResult(5, false), EvaluateGraphql(summary, []rules.Rule{newRule(rules.GraphqlCondition{Fields: []string{"password"}}, 5)}))
+		assert(t, NewEvaluationResult(5, false), EvaluateGraphql(summary, []rules.Rule{newRule(rules.GraphqlCondition{Fields: []string{"Password"}}, 5)}))
+		assert(t, NewEvaluationResult(5, false), EvaluateGraphql(summary, []rules.Rule{newRule(rules.GraphqlCondition{Fields: []string{"email"}}, 5)}))
+		assert(t, DefaultEvaluationResult(), EvaluateGraphql(summary, []rules.Rule{newRule(rules.GraphqlCondition{Fields: []string{"token"}}, 5)}))
+	})
+}
@@ -1,19 +1,21 @@
 package evaluator
 
 import (
+	"bloodhound/lib/evaluator/pipeline"
 	"bloodhound/lib/rules"
 	"bloodhound/lib/utils"
 	"slices"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/html"
 )
 
 func EvaluateHTML(document *html.Node, ruleList []rules.Rule) EvaluationResult {
-	score := 0
+	result := DefaultEvaluationResult()
 
 	if document == nil {
-		return DefaultEvaluationResult()
+		return result
 	}
 
 	var matchedRules []string
@@ -52,13 +54,13 @@ func EvaluateHTML(document *html.Node, ruleList []rules.Rule) EvaluationResult {
 					return NewEvaluationResult(0, true)
 				}
 
-				score += rule.Value
+				result.addMatch(newNodeMatch(node, rule))
 				matchedRules = append(matchedRules, rule.Name)
 			}
 		}
 	}
 
-	return NewEvaluationResult(score, false)
+	return result
 }
 
 func nodeMatchesRule(node *html.Node, rule *rules.Rule) bool {
@@ -125,6 +127,35 @@ func evaluateNodeMatchRule(node *html.Node, rule *rules.Rule) bool {
 	}
 }
 
+func newNodeMatch(node *html.Node, rule rules.Rule) pipeline.Match {
+	if node.Type == html.ElementNode {
+		return pipeline.NewNodeMatch(rule, "element", renderStartTag(node), node)
+	}
+
+	word, _ := utils.FindAny(node.Data, rule.Content.Matches)
+
+	return pipeline.NewNodeMatch(rule, "text", utils.Excerpt(node.Data, word, 80), node)
+}
+
+// Renders only the opening tag of an element, since the whole subtree can be arbitrarily large
+func renderStartTag(node *html.Node) string {
+	var builder strings.Builder
+
+	builder.WriteString("<" + node.Data)
+
+	for _, attr := range node.Attr {
+		builder.WriteString(" " + attr.Key)
+
+		if attr.Val != "" {
+			builder.WriteString("=\"" + html.EscapeString(attr.Val) + "\"")
+		}
+	}
+
+	builder.WriteString(">")
+
+	return builder.String()
+}
+
 func shouldEvaluate(node *html.Node) bool {
 	switch node.Type {
 	case html.ErrorNode,
@@ -0,0 +1,105 @@
+package evaluator
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/jsonpath"
+	"bloodhound/lib/rules"
+	"bloodhound/lib/utils"
+)
+
+func EvaluateJson(document any, ruleList []rules.Rule) EvaluationResult {
+	result := DefaultEvaluationResult()
+
+	if document == nil {
+		return result
+	}
+
+	for _, rule := range ruleList {
+		if rule.Level != rules.JsonLevel || rule.Content.Json == nil {
+			continue
+		}
+
+		node, found := findJsonNode(document, rule.Content.Json)
+
+		if !found {
+			continue
+		}
+
+		if rule.Remove {
+			return NewEvaluationResult(0, rule.Remove)
+		}
+
+		result.addMatch(pipeline.NewMatch(rule, "JSON body", describeJsonNode(node, rule.Content.Json)))
+	}
+
+	return result
+}
+
+// First node satisfying the condition, the root when the condition is empty
+func findJsonNode(document any, condition *rules.JsonCondition) (jsonpath.Node, bool) {
+	nodes := []jsonpath.Node{{Path: "$", Value: document}}
+
+	if condition.Path != "" {
+		path, err := jsonpath.Compile(condition.Path)
+
+		if err != nil {
+			return jsonpath.Node{}, false
+		}
+
+		nodes = path.Find(document)
+	}
+
+	if condition.Equals != nil {
+		var equal []jsonpath.Node
+		for _, node := range nodes {
+			if isScalar(node.Value) && jsonpath.Format(node.Value) == *condition.Equals {
+				equal = append(equal, node)
+			}
+		}
+
+		nodes = equal
+	}
+
+	if len(nodes) == 0 {
+		return jsonpath.Node{}, false
+	}
+
+	if len(condition.Keys) == 0 {
+		return nodes[0], true
+	}
+
+	for _, node := range nodes {
+		for _, descendant := range jsonpath.Descendants(node) {
+			if descendant.Key != "" && utils.ContainsAnyFold(descendant.Key, condition.Keys) {
+				return descendant, true
+			}
+		}
+	}
+
+	return jsonpath.Node{}, false
+}
+
+// Values are only shown when the rule compares them, since matched keys usually hold sensitive data
+func describeJsonNode(node jsonpath.Node, condition *rules.JsonCondition) string {
+	if condition.Equals != nil {
+		return node.Path + " = " + jsonpath.Format(node.Value)
+	}
+
+	switch node.Value.(type) {
+	case map[string]any:
+		return node.Path + " (object)"
+	case []any:
+		return node.Path + " (array)"
+	}
+
+	return node.Path
+}
+
+func isScalar(value any) bool {
+	switch value.(type) {
+	case map[string]any, []any:
+		return false
+	}
+
+	return true
+}
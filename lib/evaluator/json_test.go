@@ -0,0 +1,66 @@
+package evaluator
+
+import (
+	"bloodhound/lib/rules"
+	"encoding/json"
+	"testing"
+)
+
+func TestEvaluateJson(t *testing.T) {
+	var document any
+	json.Unmarshal([]byte(`[
+		{"id": 1, "name": "potato", "owner": {"email": "alice@example.com", "isAdmin": false}},
+		{"id": 2, "name": "milk", "owner": {"email": "bob@example.com", "isAdmin": true}}
+	]`), &document)
+
+	assert := func(t testing.TB, expected EvaluationResult, actual EvaluationResult) {
+		t.Helper()
+		if expected.Score != actual.Score || expected.Remove != actual.Remove {
+			t.Errorf("EvaluateJson; want %v; got %v", expected, actual)
+		}
+	}
+
+	newRule := func(condition rules.JsonCondition, value float64) []rules.Rule {
+		return []rules.Rule{rules.NewRule("JSON rule", rules.JsonLevel, value, false, rules.RuleContent{Json: &condition})}
+	}
+
+	equals := func(value string) *string {
+		return &value
+	}
+
+	t.Run("any JSON response", func(t *testing.T) {
+		assert(t, NewEvaluationResult(1, false), EvaluateJson(document, newRule(rules.JsonCondition{}, 1)))
+		assert(t, DefaultEvaluationResult(), EvaluateJson(nil, newRule(rules.JsonCondition{}, 1)))
+	})
+
+	t.Run("path", func(t *testing.T) {
+		assert(t, NewEvaluationResult(2, false), EvaluateJson(document, newRule(rules.JsonCondition{Path: "$[*].id"}, 2)))
+		assert(t, DefaultEvaluationResult(), EvaluateJson(document, newRule(rules.JsonCondition{Path: "$..password"}, 2)))
+	})
+
+	t.Run("path and value", func(t *testing.T) {
+		assert(t, NewEvaluationResult(3, false), EvaluateJson(document, newRule(rules.JsonCondition{Path: "$..isAdmin", Equals: equals("false")}, 3)))
+		assert(t, NewEvaluationResult(3, false), EvaluateJson(document, newRule(rules.JsonCondition{Path: "$[1].id", Equals: equals("2")}, 3)))
+		assert(t, DefaultEvaluationResult(), EvaluateJson(document, newRule(rules.JsonCondition{Path: "$[*].name", Equals: equals("bread")}, 3)))
+	})
+
+	t.Run("keys", func(t *testing.T) {
+		assert(t, NewEvaluationResult(4, false), EvaluateJson(document, newRule(rules.JsonCondition{Keys: []string{"ssn", "email"}}, 4)))
+		assert(t, DefaultEvaluationResult(), EvaluateJson(document, newRule(rules.JsonCondition{Keys: []string{"token"}}, 4)))
+		assert(t, NewEvaluationResult(4, false), EvaluateJson(document, newRule(rules.JsonCondition{Keys: []string{"isAdmin"}}, 4)))
+		assert(t, NewEvaluationResult(4, false), EvaluateJson(document, newRule(rules.JsonCondition{Keys: []string{"EMAIL"}}, 4)))
+	})
+
+	t.Run("keys below path", func(t *testing.T) {
+		assert(t, NewEvaluationResult(5, false), EvaluateJson(document, newRule(rules.JsonCondition{Path: "$[0].owner", Keys: []string{"admin"}}, 5)))
+		assert(t, DefaultEvaluationResult(), EvaluateJson(document, newRule(rules.JsonCondition{Path: "$[*].name", Keys: []string{"email"}}, 5)))
+	})
+
+	t.Run("match describes the found node", func(t *testing.T) {
+		evaluation := EvaluateJson(document, newRule(rules.JsonCondition{Path: "$..isAdmin", Equals: equals("true")}, 1))
+
+		if len(evaluation.Matches) != 1 || evaluation.Matches[0].Snippet != "$[1].owner.isAdmin = true" {
+			t.Errorf("EvaluateJson; want snippet %q; got %v", "$[1].owner.isAdmin = true", evaluation.Matches)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package evaluator
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Attributes that reference other resources, on any element
+var linkAttributes = []string{"href", "src", "action", "formaction", "poster", "data", "cite"}
+
+// Quoted strings on scripts that look like absolute URLs or absolute paths
+var scriptEndpoint = regexp.MustCompile("[\"'`]((?:https?://|/)[^\"'`\\s<>()]*)[\"'`]")
+
+// Collects every link and endpoint referenced by a document, resolved against the page URL
+func ExtractLinks(document *html.Node, pageUrl string) []pipeline.Link {
+	base, err := url.Parse(pageUrl)
+
+	if err != nil || document == nil {
+		return nil
+	}
+
+	var links []pipeline.Link
+	seen := make(map[string]bool)
+
+	add := func(reference string, via string) {
+		link, valid := resolveLink(base, reference)
+
+		if !valid || seen[link] || link == pageUrl {
+			return
+		}
+
+		seen[link] = true
+		links = append(links, pipeline.NewLink(link, via))
+	}
+
+	// Base element changes how every relative link of the document is resolved
+	for node := range document.Descendants() {
+		if node.Type == html.ElementNode && node.Data == "base" {
+			if href, found := getAttrMap(node.Attr)["href"]; found {
+				if resolved, err := base.Parse(href); err == nil {
+					base = resolved
+				}
+			}
+
+			break
+		}
+	}
+
+	for node := range document.Descendants() {
+		switch node.Type {
+		case html.ElementNode:
+			if node.Data == "base" {
+				continue
+			}
+
+			for _, attr := range node.Attr {
+				if isLinkAttribute(attr) {
+					add(attr.Val, node.Data+"["+attr.Key+"]")
+				}
+			}
+
+		case html.TextNode:
+			if node.Parent == nil || node.Parent.Type != html.ElementNode || node.Parent.Data != "script" {
+				continue
+			}
+
+			for _, match := range scriptEndpoint.FindAllStringSubmatch(node.Data, -1) {
+				add(match[1], "script")
+			}
+		}
+	}
+
+	return links
+}
+
+func isLinkAttribute(attr html.Attribute) bool {
+	for _, key := range linkAttributes {
+		if attr.Key == key {
+			return true
+		}
+	}
+
+	// Custom data attributes are only considered when their value looks like a URL
+	if strings.HasPrefix(attr.Key, "data-") {
+		value := strings.TrimSpace(attr.Val)
+
+		return strings.HasPrefix(value, "/") || strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") ||
+			strings.HasPrefix(value, "./") || strings.HasPrefix(value, "../")
+	}
+
+	return false
+}
+
+// Resolves a reference against the page URL, keeping only HTTP links without fragment
+func resolveLink(base *url.URL, reference string) (string, bool) {
+	reference = strings.TrimSpace(reference)
+
+	if reference == "" || strings.HasPrefix(reference, "#") {
+		return "", false
+	}
+
+	resolved, err := base.Parse(reference)
+
+	if err != nil {
+		return "", false
+	}
+
+	if resolved.Scheme != "http" && resolved.Scheme != "https" {
+		return "", false
+	}
+
+	resolved.Fragment = ""
+	resolved.RawFragment = ""
+
+	return resolved.String(), true
+}
@@ -0,0 +1,95 @@
+package evaluator
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"testing"
+)
+
+func TestExtractLinks(t *testing.T) {
+	page := `<!DOCTYPE html>
+		<html>
+		<head>
+			<link rel="stylesheet" href="/static/main.css">
+		</head>
+		<body>
+			<a href="/account/settings#billing">Settings</a>
+			<a href="reset">Reset</a>
+			<a href="mailto:support@localhost">Support</a>
+			<a href="#top">Top</a>
+			<form action="https://auth.localhost/login"></form>
+			<div data-endpoint="/api/v1/users" data-title="Users"></div>
+			<script>
+				fetch('/api/v1/search?q=' + query)
+				const docs = "https://localhost/docs"
+				const ratio = "1/2"
+			</script>
+		</body>
+		</html>`
+
+	links := ExtractLinks(getHTMLDocument(page), "http://localhost/account/profile")
+
+	expected := []pipeline.Link{
+		pipeline.NewLink("http://localhost/static/main.css", "link[href]"),
+		pipeline.NewLink("http://localhost/account/settings", "a[href]"),
+		pipeline.NewLink("http://localhost/account/reset", "a[href]"),
+		pipeline.NewLink("https://auth.localhost/login", "form[action]"),
+		pipeline.NewLink("http://localhost/api/v1/users", "div[data-endpoint]"),
+		pipeline.NewLink("http://localhost/api/v1/search?q=", "script"),
+		pipeline.NewLink("https://localhost/docs", "script"),
+	}
+
+	if len(expected) != len(links) {
+		t.Fatalf("ExtractLinks; want %+v; got %+v", expected, links)
+	}
+
+	for i := range expected {
+		if expected[i] != links[i] {
+			t.Errorf("ExtractLinks; want %+v; got %+v", expected[i], links[i])
+		}
+	}
+
+	t.Run("base element", func(t *testing.T) {
+		page := `<html><head><base href="http://cdn.localhost/app/"></head><body><a href="page">Page</a></body></html>`
+		links := ExtractLinks(getHTMLDocument(page), "http://localhost/")
+
+		if len(links) != 1 || links[0].Url != "http://cdn.localhost/app/page" {
+			t.Errorf("ExtractLinks; want link resolved against base; got %+v", links)
+		}
+	})
+
+	t.Run("page is not available", func(t *testing.T) {
+		if links := ExtractLinks(nil, "http://localhost/"); len(links) != 0 {
+			t.Errorf("ExtractLinks; want no links; got %+v", links)
+		}
+	})
+}
+
+func TestScope(t *testing.T) {
+	scope := NewScope([]string{"localhost", "*.example.com"})
+
+	assert := func(t *testing.T, url string, expected bool) {
+		if actual := scope.Contains(url); expected != actual {
+			t.Errorf("Contains(%s); want %v; got %v", url, expected, actual)
+		}
+	}
+
+	t.Run("exact host", func(t *testing.T) {
+		assert(t, "http://localhost:5555/login", true)
+		assert(t, "http://auth.localhost/login", false)
+	})
+
+	t.Run("wildcard host", func(t *testing.T) {
+		assert(t, "https://api.example.com/v1", true)
+		assert(t, "https://a.b.example.com/", true)
+		assert(t, "https://example.com/", false)
+		assert(t, "https://notexample.com/", false)
+	})
+
+	t.Run("scope from input URLs", func(t *testing.T) {
+		scope := NewScopeFromUrls([]string{"http://localhost:5555/search", "https://app.example.com/"})
+
+		if !scope.Contains("https://app.example.com/login") || scope.Contains("https://api.example.com/") {
+			t.Error("NewScopeFromUrls; want only input hosts in scope")
+		}
+	})
+}
@@ -1,78 +1,439 @@
 package evaluator
 
 import (
+	"bloodhound/lib/apispec"
+	"bloodhound/lib/archive"
 	"bloodhound/lib/client"
+	"bloodhound/lib/discovery"
 	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/graphql"
+	"bloodhound/lib/recon"
 	"bloodhound/lib/rules"
+	"bloodhound/lib/scoring"
+	"bloodhound/lib/wordlist"
+	"slices"
 	"sort"
 
 	log "github.com/sirupsen/logrus"
 )
 
 type EvaluationResult struct {
-	Score  int
-	Remove bool
+	Score   float64
+	Remove  bool
+	Matches []pipeline.Match
 }
 
-func NewEvaluationResult(score int, remove bool) EvaluationResult {
+func NewEvaluationResult(score float64, remove bool) EvaluationResult {
 	return EvaluationResult{
 		Score:  score,
 		Remove: remove,
 	}
 }
 
+func (result *EvaluationResult) addMatch(match pipeline.Match) {
+	result.Score += match.Rule.Value
+	result.Matches = append(result.Matches, match)
+}
+
 func DefaultEvaluationResult() EvaluationResult {
 	return NewEvaluationResult(0, false)
 }
 
+type Config struct {
+	Client client.ClientConfig
+
+	// Saved responses to evaluate instead of requesting targets, optional
+	Replay *archive.Archive
+
+	// Stores every retrieved response, optional
+	Store *archive.Writer
+
+	// Collects words from every retrieved target, optional
+	Words *wordlist.Collector
+
+	// Number of link levels to follow from input targets, 0 disables link extraction
+	Depth int
+
+	// Hosts that discovered links are allowed on, defaults to the hosts of input targets
+	Scope *Scope
+
+	// What to do with responses matching the "not found" response of their host
+	SoftNotFound pipeline.SoftNotFoundMode
+
+	// Keep a single representative of near-duplicate targets
+	Cluster bool
+
+	// Send a variant of targets with query parameters, to find where parameters are reflected
+	ProbeReflections bool
+
+	// Find hidden query parameters of targets without any, using these words and names found on the same host
+	DiscoverParameters bool
+	ParameterWords     []string
+
+	// Confirm GraphQL endpoints and summarize their schema
+	ProbeGraphql bool
+
+	// Look for API specs on well-known locations of each input host, and evaluate their endpoints
+	DiscoverSpecs bool
+
+	// Read robots.txt, sitemaps, security.txt and crossdomain.xml of each input host, and evaluate the URLs found on them
+	Recon bool
+}
+
+// Stages that send their own requests, shared across waves so per host state (e.g. soft 404 fingerprints) is kept
+type requesters struct {
+	limiter      *client.RateLimiter
+	softNotFound *pipeline.SoftNotFoundDetector
+	reflections  *pipeline.ReflectionProber
+	parameters   *discovery.ParameterFinder
+	graphql      *graphql.Analyzer
+	specs        *apispec.Finder
+	recon        *recon.Harvester
+}
+
+// Active stages are disabled when replaying saved responses, since no request can be sent
+func newRequesters(config Config) *requesters {
+	limiter := client.NewRateLimiter(config.Client.Rate)
+	bloodhoundClient := client.NewClient(config.Client)
+
+	requesters := &requesters{
+		limiter: limiter,
+	}
+
+	if config.Replay != nil {
+		return requesters
+	}
+
+	if config.SoftNotFound != "" && config.SoftNotFound != pipeline.SoftNotFoundOff {
+		requesters.softNotFound = pipeline.NewSoftNotFoundDetector(bloodhoundClient, limiter)
+	}
+
+	if config.ProbeReflections {
+		requesters.reflections = pipeline.NewReflectionProber(bloodhoundClient, limiter)
+	}
+
+	if config.DiscoverParameters {
+		words := config.ParameterWords
+		if len(words) == 0 {
+			words = discovery.DefaultParameterWords()
+		}
+
+		requesters.parameters = discovery.NewParameterFinder(bloodhoundClient, limiter, words)
+	}
+
+	if config.ProbeGraphql {
+		requesters.graphql = graphql.NewAnalyzer(bloodhoundClient, limiter)
+	}
+
+	if config.DiscoverSpecs {
+		requesters.specs = apispec.NewFinder(bloodhoundClient, limiter)
+	}
+
+	if config.Recon {
+		requesters.recon = recon.NewHarvester(bloodhoundClient, limiter)
+	}
+
+	return requesters
+}
+
+// Targets are usually created with the source they were read from, the same URL given more than once is evaluated once
 // TODO: Add stopwatch
-func Evaluate(targetUrls []string, ruleset *rules.Ruleset, clientConfig client.ClientConfig) []pipeline.Context {
+func Evaluate(targets []pipeline.Context, ruleset *rules.Ruleset, config Config) []pipeline.Context {
+	model := scoring.NewModel(ruleset.Scoring, ruleset.Rules)
+
+	input := newTargetList(nil)
+	var targetUrls []string
+
+	for _, target := range targets {
+		context, isNew := input.add(target.Url)
+
+		if isNew {
+			targetUrls = append(targetUrls, target.Url)
+		}
+
+		for _, source := range target.Sources {
+			context.AddSource(source)
+		}
+	}
+
+	contexts := input.contexts
+
+	scope := config.Scope
+	if scope == nil {
+		scope = NewScopeFromUrls(targetUrls)
+	}
+
+	// Every request shares the same rate limiter
+	requesters := newRequesters(config)
+	defer requesters.limiter.Stop()
+
+	// URLs found on recon files and endpoints of API specs are evaluated with the input targets
+	if requesters.recon != nil {
+		contexts = HarvestRecon(requesters.recon, contexts, scope)
+	}
+
+	if requesters.specs != nil {
+		contexts = DiscoverSpecs(requesters.specs, contexts, scope)
+	}
+
+	seen := make(map[string]bool)
+	for _, context := range contexts {
+		seen[context.Url] = true
+	}
+
+	var results []pipeline.Context
+
+	/*
+		Targets are evaluated in waves, one per depth level.
+			In-scope links found on each wave are pushed back as the input of the next one,
+			until the configured depth is reached or no new link is found
+	*/
+	// Position of every evaluated target on the results, so later links can add sources to it
+	evaluated := make(map[string]int)
+	changed := make(map[string]bool)
+
+	for depth := 0; len(contexts) != 0; depth++ {
+		wave := evaluatePipeline(contexts, ruleset, model, config, requesters)
+
+		for _, context := range wave {
+			evaluated[context.Url] = len(results)
+			results = append(results, context)
+		}
+
+		// Links found on several pages keep every page as a source, including links to targets that were already
+		// evaluated. Links of the last wave are only used as sources
+		next := newTargetList(nil)
+
+		for _, context := range wave {
+			for _, link := range context.Links {
+				if !scope.Contains(link.Url) {
+					continue
+				}
+
+				if index, found := evaluated[link.Url]; found {
+					sources := len(results[index].Sources)
+					results[index].AddSource(context.LinkSource(link))
+					changed[link.Url] = changed[link.Url] || len(results[index].Sources) != sources
+
+					continue
+				}
+
+				if seen[link.Url] || depth >= config.Depth {
+					continue
+				}
+
+				discovered, isNew := next.add(link.Url)
+
+				if isNew {
+					discovered.Depth = depth + 1
+				}
+
+				discovered.AddSource(context.LinkSource(link))
+			}
+		}
+
+		if depth >= config.Depth {
+			break
+		}
+
+		contexts = next.contexts
+
+		for _, context := range contexts {
+			seen[context.Url] = true
+		}
+
+		log.WithFields(log.Fields{
+			"depth":      depth + 1,
+			"discovered": len(contexts),
+		}).Info("Finished extracting links")
+	}
+
+	results = reevaluateProvenance(results, changed, ruleset, model)
+
+	// Rank URLs by score
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+
+	if config.Cluster {
+		results = Cluster(results)
+	}
+
+	return results
+}
+
+// Provenance rules are evaluated when a target enters the pipeline, targets that got sources afterwards are evaluated again
+func reevaluateProvenance(results []pipeline.Context, changed map[string]bool, ruleset *rules.Ruleset, model scoring.Model) []pipeline.Context {
+	provenanceRules := ruleset.GetRules(rules.ProvenanceLevel)
+
+	if len(changed) == 0 || len(provenanceRules) == 0 {
+		return results
+	}
+
+	var kept []pipeline.Context
+
+	for _, context := range results {
+		if !changed[context.Url] {
+			kept = append(kept, context)
+			continue
+		}
+
+		context.Matches = slices.DeleteFunc(context.Matches, func(match pipeline.Match) bool {
+			return match.Rule.Level == rules.ProvenanceLevel
+		})
+
+		evaluation := EvaluateProvenance(context.Sources, provenanceRules)
+
+		if evaluation.Remove {
+			continue
+		}
+
+		context.AddMatches(evaluation.Matches)
+		context.Score = model.Score(context.MatchedRules())
+		kept = append(kept, context)
+	}
+
+	return kept
+}
+
+func evaluatePipeline(contexts []pipeline.Context, ruleset *rules.Ruleset, model scoring.Model, config Config, requesters *requesters) []pipeline.Context {
 	log.WithFields(log.Fields{
-		"targetsSize": len(targetUrls),
+		"targetsSize": len(contexts),
 		"rulesetSize": len(ruleset.Rules),
 	}).Trace("Initializing evaluation pipeline")
 
-	maxChannelSize := len(targetUrls)
+	maxChannelSize := len(contexts)
 
 	// Put context into pipeline
 	inputChannel := make(chan pipeline.Context, maxChannelSize)
-	go pipelineInput(targetUrls, inputChannel)
+	go pipelineInput(contexts, inputChannel)
 
 	// Apply resource name rules
 	resourceLevelResultChannel := make(chan pipeline.Context, maxChannelSize)
 	go applyResourceNameRules(ruleset, inputChannel, resourceLevelResultChannel)
 
-	// Retrieve resource
+	// Apply rules on how targets were found, which doesn't need a response either
+	provenanceLevelResultChannel := make(chan pipeline.Context, maxChannelSize)
+	go applyFactRules(ruleset, rules.ProvenanceLevel, evaluateProvenance, resourceLevelResultChannel, provenanceLevelResultChannel)
+	resourceLevelResultChannel = provenanceLevelResultChannel
+
+	// Confirm GraphQL endpoints before retrieving them, so they're kept even if a plain request fails
+	if requesters.graphql != nil {
+		graphqlResultChannel := make(chan pipeline.Context, maxChannelSize)
+		go pipeline.ProbeGraphql(requesters.graphql, resourceLevelResultChannel, graphqlResultChannel)
+		resourceLevelResultChannel = graphqlResultChannel
+	}
+
+	// Retrieve resource, or read it from saved responses
 	requestResultChannel := make(chan pipeline.Context, maxChannelSize)
-	go pipeline.RetrieveResource(clientConfig, resourceLevelResultChannel, requestResultChannel)
+	if config.Replay != nil {
+		go pipeline.ReplayResource(config.Replay, resourceLevelResultChannel, requestResultChannel)
+	} else {
+		go pipeline.RetrieveResource(config.Client, requesters.limiter, config.Store, resourceLevelResultChannel, requestResultChannel)
+	}
+
+	// Detect soft 404 responses
+	if requesters.softNotFound != nil {
+		softNotFoundResultChannel := make(chan pipeline.Context, maxChannelSize)
+		go pipeline.DetectSoftNotFound(requesters.softNotFound, config.SoftNotFound, requestResultChannel, softNotFoundResultChannel)
+		requestResultChannel = softNotFoundResultChannel
+	}
+
+	// Probe where query parameters are reflected, only the original response is stored and evaluated
+	if requesters.reflections != nil {
+		reflectionsResultChannel := make(chan pipeline.Context, maxChannelSize)
+		go pipeline.ProbeReflections(requesters.reflections, requestResultChannel, reflectionsResultChannel)
+		requestResultChannel = reflectionsResultChannel
+	}
+
+	// Collect words for the target word list, before content rules remove any target
+	if config.Words != nil {
+		wordsResultChannel := make(chan pipeline.Context, maxChannelSize)
+		go collectWords(config.Words, requestResultChannel, wordsResultChannel)
+		requestResultChannel = wordsResultChannel
+	}
+
+	// Detect technologies and apply rules on them
+	techResultChannel := make(chan pipeline.Context, maxChannelSize)
+	go pipeline.DetectTechnologies(requestResultChannel, techResultChannel)
+
+	techLevelResultChannel := make(chan pipeline.Context, maxChannelSize)
+	go applyFactRules(ruleset, rules.TechLevel, evaluateTechnologies, techResultChannel, techLevelResultChannel)
+
+	// Detect secrets and apply rules on them
+	secretsResultChannel := make(chan pipeline.Context, maxChannelSize)
+	go pipeline.DetectSecrets(techLevelResultChannel, secretsResultChannel)
+
+	findingLevelResultChannel := make(chan pipeline.Context, maxChannelSize)
+	go applyFactRules(ruleset, rules.FindingLevel, evaluateFindings, secretsResultChannel, findingLevelResultChannel)
+
+	// Group forms with their fields and apply rules on them
+	formsResultChannel := make(chan pipeline.Context, maxChannelSize)
+	go analyzeForms(findingLevelResultChannel, formsResultChannel)
+
+	formLevelResultChannel := make(chan pipeline.Context, maxChannelSize)
+	go applyFactRules(ruleset, rules.FormLevel, evaluateForms, formsResultChannel, formLevelResultChannel)
+
+	// Apply rules on reflected parameters, targets that weren't probed have no reflections
+	reflectionLevelResultChannel := make(chan pipeline.Context, maxChannelSize)
+	go applyFactRules(ruleset, rules.ReflectionLevel, evaluateReflections, formLevelResultChannel, reflectionLevelResultChannel)
+	requestResultChannel = reflectionLevelResultChannel
+
+	// Find hidden parameters, after forms are analyzed so their field names can be used as candidates
+	if requesters.parameters != nil {
+		parametersResultChannel := make(chan pipeline.Context, maxChannelSize)
+		go discoverParameters(requesters.parameters, requestResultChannel, parametersResultChannel)
+		requestResultChannel = parametersResultChannel
+	}
+
+	parameterLevelResultChannel := make(chan pipeline.Context, maxChannelSize)
+	go applyFactRules(ruleset, rules.ParameterLevel, evaluateParameters, requestResultChannel, parameterLevelResultChannel)
+
+	graphqlLevelResultChannel := make(chan pipeline.Context, maxChannelSize)
+	go applyFactRules(ruleset, rules.GraphqlLevel, evaluateGraphql, parameterLevelResultChannel, graphqlLevelResultChannel)
+
+	// Apply rules on the body of JSON responses, which content rules only see as a single text node
+	jsonLevelResultChannel := make(chan pipeline.Context, maxChannelSize)
+	go applyFactRules(ruleset, rules.JsonLevel, evaluateJson, graphqlLevelResultChannel, jsonLevelResultChannel)
+	requestResultChannel = jsonLevelResultChannel
 
 	// Apply content level evaluation
 	contentLevelResultChannel := make(chan pipeline.Context, maxChannelSize)
 	go applyContentRules(ruleset, requestResultChannel, contentLevelResultChannel)
 
+	// Extract links to feed the next wave
+	if config.Depth > 0 {
+		linksResultChannel := make(chan pipeline.Context, maxChannelSize)
+		go extractLinks(contentLevelResultChannel, linksResultChannel)
+		contentLevelResultChannel = linksResultChannel
+	}
+
 	log.WithFields(log.Fields{
-		"targetsSize": len(targetUrls),
+		"targetsSize": len(contexts),
 		"rulesetSize": len(ruleset.Rules),
 	}).Info("Initialized evaluation pipeline")
 
-	return pipelineOutput(contentLevelResultChannel)
+	return pipelineOutput(model, contentLevelResultChannel)
 }
 
-func pipelineInput(targetUrls []string, out chan<- pipeline.Context) {
+func pipelineInput(contexts []pipeline.Context, out chan<- pipeline.Context) {
 	defer close(out)
 
-	for _, targetUrl := range targetUrls {
+	for _, context := range contexts {
 		log.WithFields(log.Fields{
-			"target": targetUrl,
+			"target": context.Url,
+			"depth":  context.Depth,
 		}).Trace("Created new context")
 
-		out <- pipeline.NewContext(targetUrl)
+		out <- context
 	}
 }
 
-func pipelineOutput(in <-chan pipeline.Context) []pipeline.Context {
+func pipelineOutput(model scoring.Model, in <-chan pipeline.Context) []pipeline.Context {
 	var contexts []pipeline.Context
 	for context := range in {
+		context.Score = model.Score(context.MatchedRules())
+
 		log.WithFields(log.Fields{
 			"target": context.Url,
 			"score":  context.Score,
@@ -81,11 +442,6 @@ func pipelineOutput(in <-chan pipeline.Context) []pipeline.Context {
 		contexts = append(contexts, context)
 	}
 
-	// Rank URLs by score
-	sort.Slice(contexts, func(i, j int) bool {
-		return contexts[i].Score > contexts[j].Score
-	})
-
 	return contexts
 }
 
@@ -106,7 +462,7 @@ func applyResourceNameRules(ruleset *rules.Ruleset, in <-chan pipeline.Context,
 		}).Trace("Finished resource level rule evaluation")
 
 		if !evaluation.Remove {
-			context.AddScore(evaluation.Score)
+			context.AddMatches(evaluation.Matches)
 			out <- context
 		}
 	}
@@ -130,8 +486,124 @@ func applyContentRules(ruleset *rules.Ruleset, in <-chan pipeline.Context, out c
 		}).Trace("Finished content level evaluation")
 
 		if !evaluation.Remove {
-			context.AddScore(evaluation.Score)
+			context.AddMatches(evaluation.Matches)
 			out <- context
 		}
 	}
 }
+
+// Applies rules on facts detected on the context (e.g. technologies, findings), stages differ only on how rules are evaluated
+func applyFactRules(ruleset *rules.Ruleset, level rules.Level, evaluate func(pipeline.Context, []rules.Rule) EvaluationResult, in <-chan pipeline.Context, out chan<- pipeline.Context) {
+	defer close(out)
+	levelRules := ruleset.GetRules(level)
+
+	for context := range in {
+		evaluation := evaluate(context, levelRules)
+
+		log.WithFields(log.Fields{
+			"target":     context.Url,
+			"level":      level,
+			"evaluation": evaluation,
+		}).Trace("Finished rule evaluation")
+
+		if !evaluation.Remove {
+			context.AddMatches(evaluation.Matches)
+			out <- context
+		}
+	}
+}
+
+func evaluateTechnologies(context pipeline.Context, ruleList []rules.Rule) EvaluationResult {
+	return EvaluateTechnologies(context.Technologies, ruleList)
+}
+
+func evaluateFindings(context pipeline.Context, ruleList []rules.Rule) EvaluationResult {
+	return EvaluateFindings(context.Findings, ruleList)
+}
+
+func evaluateForms(context pipeline.Context, ruleList []rules.Rule) EvaluationResult {
+	return EvaluateForms(context.Forms, ruleList)
+}
+
+func evaluateReflections(context pipeline.Context, ruleList []rules.Rule) EvaluationResult {
+	return EvaluateReflections(context.Reflections, ruleList)
+}
+
+func evaluateParameters(context pipeline.Context, ruleList []rules.Rule) EvaluationResult {
+	return EvaluateParameters(context.Parameters, ruleList)
+}
+
+func evaluateGraphql(context pipeline.Context, ruleList []rules.Rule) EvaluationResult {
+	return EvaluateGraphql(context.Graphql, ruleList)
+}
+
+func evaluateJson(context pipeline.Context, ruleList []rules.Rule) EvaluationResult {
+	return EvaluateJson(context.Json, ruleList)
+}
+
+func evaluateProvenance(context pipeline.Context, ruleList []rules.Rule) EvaluationResult {
+	return EvaluateProvenance(context.Sources, ruleList)
+}
+
+func collectWords(collector *wordlist.Collector, in <-chan pipeline.Context, out chan<- pipeline.Context) {
+	defer close(out)
+
+	for context := range in {
+		collector.Collect(context.Url, context.Content)
+
+		log.WithFields(log.Fields{
+			"target": context.Url,
+		}).Trace("Collected words from target")
+
+		out <- context
+	}
+}
+
+func analyzeForms(in <-chan pipeline.Context, out chan<- pipeline.Context) {
+	defer close(out)
+
+	for context := range in {
+		context.Forms = AnalyzeForms(context.Content, context.Url)
+
+		log.WithFields(log.Fields{
+			"target": context.Url,
+			"forms":  len(context.Forms),
+		}).Trace("Analyzed forms of target")
+
+		out <- context
+	}
+}
+
+func discoverParameters(finder *discovery.ParameterFinder, in <-chan pipeline.Context, out chan<- pipeline.Context) {
+	defer close(out)
+
+	for context := range in {
+		HarvestParameters(finder, context)
+
+		if !HasQuery(context.Url) {
+			context.Parameters = finder.Find(context.Url)
+
+			log.WithFields(log.Fields{
+				"target":     context.Url,
+				"parameters": len(context.Parameters),
+			}).Debug("Finished parameter discovery")
+		}
+
+		out <- context
+	}
+}
+
+func extractLinks(in <-chan pipeline.Context, out chan<- pipeline.Context) {
+	defer close(out)
+
+	for context := range in {
+		context.Links = ExtractLinks(context.Content, context.Url)
+
+		log.WithFields(log.Fields{
+			"target": context.Url,
+			"links":  len(context.Links),
+		}).Trace("Extracted links from target")
+
+		out <- context
+	}
+}
@@ -0,0 +1,102 @@
+package evaluator
+
+import (
+	"bloodhound/lib/discovery"
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/rules"
+	"bloodhound/lib/utils"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Parameter names referenced by scripts, on query strings (?page=, &sort=) or read from URLSearchParams (.get("page"))
+var scriptParameter = regexp.MustCompile(`[?&]([A-Za-z_][\w\-]{0,39})=|\.(?:get|has|getAll)\(\s*["']([A-Za-z_][\w\-]{0,39})["']\s*\)`)
+
+// Adds the names of form fields and parameters referenced by scripts as candidates for every target of the host
+func HarvestParameters(finder *discovery.ParameterFinder, context pipeline.Context) {
+	var fields []string
+	for _, form := range context.Forms {
+		for _, field := range form.Fields {
+			fields = append(fields, field.Name)
+		}
+	}
+
+	finder.Harvest(context.Url, fields, discovery.FormSource)
+	finder.Harvest(context.Url, ScriptParameters(context.Content), discovery.ScriptSource)
+}
+
+func ScriptParameters(document *html.Node) []string {
+	if document == nil {
+		return nil
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+
+	for node := range document.Descendants() {
+		if node.Type != html.TextNode || node.Parent == nil || node.Parent.Type != html.ElementNode || node.Parent.Data != "script" {
+			continue
+		}
+
+		for _, match := range scriptParameter.FindAllStringSubmatch(node.Data, -1) {
+			name := match[1] + match[2]
+
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names
+}
+
+func HasQuery(targetUrl string) bool {
+	parsed, err := url.Parse(targetUrl)
+	return err == nil && parsed.RawQuery != ""
+}
+
+func EvaluateParameters(parameters []discovery.Parameter, ruleList []rules.Rule) EvaluationResult {
+	result := DefaultEvaluationResult()
+
+	for _, rule := range ruleList {
+		if rule.Level != rules.ParameterLevel || rule.Content.Parameter == nil {
+			continue
+		}
+
+		for _, parameter := range parameters {
+			if !parameterMatchesCondition(&parameter, rule.Content.Parameter) {
+				continue
+			}
+
+			if rule.Remove {
+				return NewEvaluationResult(0, rule.Remove)
+			}
+
+			result.addMatch(pipeline.NewMatch(rule, "parameter", parameter.Name+" ("+parameter.Reason+", from "+parameter.Source+")"))
+
+			break
+		}
+	}
+
+	return result
+}
+
+func parameterMatchesCondition(parameter *discovery.Parameter, condition *rules.ParameterCondition) bool {
+	if len(condition.Names) != 0 && !utils.ContainsAny(parameter.Name, condition.Names) {
+		return false
+	}
+
+	if condition.Reason != "" && !strings.EqualFold(condition.Reason, parameter.Reason) {
+		return false
+	}
+
+	if condition.Source != "" && !strings.EqualFold(condition.Source, parameter.Source) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,58 @@
+package evaluator
+
+import (
+	"bloodhound/lib/discovery"
+	"bloodhound/lib/rules"
+	"slices"
+	"testing"
+)
+
+func TestScriptParameters(t *testing.T) {
+	page := `<html><body>
+		<a href="/search?q=shoes">Search</a>
+		<script>
+			const params = new URLSearchParams(location.search)
+			if (params.get("preview") === "1") { fetch("/api/items?include_hidden=true&limit=" + limit) }
+		</script>
+		</body></html>`
+
+	expected := []string{"include_hidden", "limit", "preview"}
+	names := ScriptParameters(getHTMLDocument(page))
+	slices.Sort(names)
+
+	if !slices.Equal(expected, names) {
+		t.Errorf("ScriptParameters; want %v; got %v", expected, names)
+	}
+}
+
+func TestEvaluateParameters(t *testing.T) {
+	parameters := []discovery.Parameter{
+		{Name: "debug", Reason: discovery.ChangedParameter, Source: discovery.WordlistSource},
+		{Name: "callback", Reason: discovery.ReflectedParameter, Source: discovery.ScriptSource},
+	}
+
+	assert := func(t testing.TB, expected EvaluationResult, actual EvaluationResult) {
+		t.Helper()
+		if expected.Score != actual.Score || expected.Remove != actual.Remove {
+			t.Errorf("EvaluateParameters; want %v; got %v", expected, actual)
+		}
+	}
+
+	newRule := func(condition rules.ParameterCondition, value float64) rules.Rule {
+		return rules.NewRule("Parameter rule", rules.ParameterLevel, value, false, rules.RuleContent{Parameter: &condition})
+	}
+
+	t.Run("any parameter", func(t *testing.T) {
+		assert(t, NewEvaluationResult(1, false), EvaluateParameters(parameters, []rules.Rule{newRule(rules.ParameterCondition{}, 1)}))
+	})
+
+	t.Run("parameter name", func(t *testing.T) {
+		rule := newRule(rules.ParameterCondition{Names: []string{"debug", "test"}}, 3)
+		assert(t, NewEvaluationResult(3, false), EvaluateParameters(parameters, []rules.Rule{rule}))
+	})
+
+	t.Run("conditions must hold on a single parameter", func(t *testing.T) {
+		rule := newRule(rules.ParameterCondition{Names: []string{"debug"}, Reason: discovery.ReflectedParameter}, 3)
+		assert(t, DefaultEvaluationResult(), EvaluateParameters(parameters, []rules.Rule{rule}))
+	})
+}
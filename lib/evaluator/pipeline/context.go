@@ -1,11 +1,102 @@
 package pipeline
 
-import "golang.org/x/net/html"
+import (
+	"bloodhound/lib/apispec"
+	"bloodhound/lib/discovery"
+	"bloodhound/lib/graphql"
+	"bloodhound/lib/rules"
+	"bloodhound/lib/secrets"
+	"bloodhound/lib/tech"
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"golang.org/x/net/html"
+)
+
+type Match struct {
+	Rule rules.Rule
+
+	// Which part of the target matched the rule (e.g. URL path, element, text)
+	Location string
+
+	// Excerpt of the target that matched the rule
+	Snippet string
+
+	// Matched HTML node, only available for content level rules
+	Node *html.Node
+}
+
+type Response struct {
+	Method        string
+	RequestHeader http.Header
+	StatusCode    int
+	Header        http.Header
+	Body          []byte
+}
+
+// Link found on the content of a target
+type Link struct {
+	Url string
+
+	// Element and attribute the link was found on (e.g. a[href]), or script for endpoints found on scripts
+	Via string
+}
 
 type Context struct {
-	Url     string
-	Content *html.Node
-	Score   int
+	Url      string
+	Content  *html.Node
+	Response *Response
+	Score    float64
+	Matches  []Match
+
+	// Body of successful JSON responses, decoded with encoding/json
+	Json any
+
+	// Every way the target was found, the first one is how it was found first
+	Sources []Source
+
+	// Number of pages between the input and the target, input targets have depth 0
+	Depth int
+	Links []Link
+
+	// Response looks like the response of the host for paths that don't exist
+	SoftNotFound bool
+
+	// Technologies detected on the response
+	Technologies []tech.Technology
+
+	// Secrets found on the response, with redacted previews
+	Findings []secrets.Finding
+
+	Forms []Form
+
+	// Where query parameters are reflected on the response, only set when reflections are probed
+	Reflections []Reflection
+
+	// Hidden query parameters accepted by the target, only set when parameters are discovered
+	Parameters []discovery.Parameter
+
+	// Summary of the GraphQL endpoint, only set for confirmed endpoints when GraphQL is probed
+	Graphql *graphql.Summary
+
+	// API spec served by the target, only set when specs are discovered
+	Spec *apispec.Spec
+
+	// Endpoint of the API spec the target was created from
+	Endpoint *apispec.Endpoint
+
+	// Response of the probe that discovered the target, only set for brute-forced targets
+	DiscoveryHit *discovery.ResponseStats
+
+	// Differences with the baseline run (e.g. new, status), only set when comparing with a baseline
+	Changes []string
+
+	// Multiplier of the score used for ranking, only set on new targets when comparing with a baseline
+	Boost float64
+
+	// Near-duplicates of the target, only set when results are clustered
+	Duplicates []string
 }
 
 func NewContext(targetUrl string) Context {
@@ -15,6 +106,78 @@ func NewContext(targetUrl string) Context {
 	}
 }
 
-func (context *Context) AddScore(score int) {
-	context.Score += score
+func NewLink(url string, via string) Link {
+	return Link{
+		Url: url,
+		Via: via,
+	}
+}
+
+func NewMatch(rule rules.Rule, location string, snippet string) Match {
+	return Match{
+		Rule:     rule,
+		Location: location,
+		Snippet:  snippet,
+	}
+}
+
+func NewNodeMatch(rule rules.Rule, location string, snippet string, node *html.Node) Match {
+	match := NewMatch(rule, location, snippet)
+	match.Node = node
+
+	return match
+}
+
+// Stores the response on the context, parsing the content of successful responses
+func (context *Context) SetResponse(response *Response) {
+	context.Response = response
+
+	if response.StatusCode == http.StatusOK {
+		bodyReader := bytes.NewReader(response.Body)
+		document, _ := html.Parse(bodyReader)
+		context.Content = document
+
+		if isJson(response.Body) {
+			json.Unmarshal(response.Body, &context.Json)
+		}
+	}
+}
+
+// Confirmed GraphQL endpoints and endpoints of API specs are evaluated even when a plain GET request fails
+func (context *Context) IsApiEndpoint() bool {
+	return context.Graphql != nil || context.Endpoint != nil
+}
+
+// Brute-forced targets are requested without following redirects and evaluated with any status that
+// matched discovery, since 401 and 403 responses are usually the interesting ones
+func (context *Context) IsDiscoveryHit() bool {
+	return context.DiscoveryHit != nil
+}
+
+// APIs don't always send a JSON content type, so the body is checked instead
+func isJson(body []byte) bool {
+	trimmed := bytes.TrimSpace(body)
+	return (bytes.HasPrefix(trimmed, []byte("{")) || bytes.HasPrefix(trimmed, []byte("["))) && json.Valid(trimmed)
+}
+
+// Score used for ranking, the score itself is kept unboosted so later runs compare against it
+func (context *Context) RankScore() float64 {
+	if context.Boost > 0 {
+		return context.Score * context.Boost
+	}
+
+	return context.Score
+}
+
+func (context *Context) AddMatches(matches []Match) {
+	context.Matches = append(context.Matches, matches...)
+}
+
+func (context *Context) MatchedRules() []rules.Rule {
+	var result []rules.Rule
+	for _, match := range context.Matches {
+		result = append(result, match.Rule)
+	}
+
+	return result
 }
@@ -0,0 +1,33 @@
+package pipeline
+
+import (
+	"bloodhound/lib/secrets"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func DetectSecrets(in <-chan Context, out chan<- Context) {
+	defer close(out)
+
+	for context := range in {
+		context.DetectSecrets()
+
+		if len(context.Findings) != 0 {
+			log.WithFields(log.Fields{
+				"target":   context.Url,
+				"findings": len(context.Findings),
+			}).Debug("Found secrets on response")
+		}
+
+		out <- context
+	}
+}
+
+// Scans the stored response for secrets, targets without response are left untouched
+func (context *Context) DetectSecrets() {
+	if context.Response == nil {
+		return
+	}
+
+	context.Findings = secrets.Scan(context.Response.Body, context.Content)
+}
@@ -0,0 +1,31 @@
+package pipeline
+
+import (
+	"bloodhound/lib/tech"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func DetectTechnologies(in <-chan Context, out chan<- Context) {
+	defer close(out)
+
+	for context := range in {
+		context.DetectTechnologies()
+
+		log.WithFields(log.Fields{
+			"target":       context.Url,
+			"technologies": context.Technologies,
+		}).Trace("Finished technology detection")
+
+		out <- context
+	}
+}
+
+// Detects technologies from the stored response, targets without response are left untouched
+func (context *Context) DetectTechnologies() {
+	if context.Response == nil {
+		return
+	}
+
+	context.Technologies = tech.Detect(context.Response.Header, context.Response.Body, context.Content)
+}
@@ -0,0 +1,34 @@
+package pipeline
+
+import "slices"
+
+type FormField struct {
+	Name string `json:"name,omitempty"`
+
+	// Input type (text, hidden, file...), or the element name for select and textarea
+	Type string `json:"type"`
+}
+
+// Form of a page, grouped together with every field that submits with it
+type Form struct {
+	// Action resolved against the page URL, the page itself when missing
+	Action      string `json:"action"`
+	Method      string `json:"method"`
+	Enctype     string `json:"enctype"`
+	CrossOrigin bool   `json:"crossOrigin"`
+
+	Fields []FormField `json:"fields,omitempty"`
+
+	// Hidden field named like an anti-CSRF token (csrf, xsrf, authenticity_token...)
+	HasCsrfToken bool `json:"hasCsrfToken"`
+
+	HasFileField     bool `json:"hasFileField"`
+	HasPasswordField bool `json:"hasPasswordField"`
+	HasEmailField    bool `json:"hasEmailField"`
+}
+
+func (form *Form) HasFieldType(fieldType string) bool {
+	return slices.ContainsFunc(form.Fields, func(field FormField) bool {
+		return field.Type == fieldType
+	})
+}
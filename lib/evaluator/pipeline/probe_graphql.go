@@ -0,0 +1,27 @@
+package pipeline
+
+import (
+	"bloodhound/lib/graphql"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Runs before resources are retrieved, since GraphQL endpoints usually answer plain GET requests with errors
+func ProbeGraphql(analyzer *graphql.Analyzer, in <-chan Context, out chan<- Context) {
+	defer close(out)
+
+	for context := range in {
+		if graphql.IsCandidate(context.Url) {
+			context.Graphql = analyzer.Analyze(context.Url)
+
+			if context.Graphql != nil {
+				log.WithFields(log.Fields{
+					"target":        context.Url,
+					"introspection": context.Graphql.Introspection,
+				}).Debug("Confirmed GraphQL endpoint")
+			}
+		}
+
+		out <- context
+	}
+}
@@ -0,0 +1,412 @@
+package pipeline
+
+import (
+	"bloodhound/lib/client"
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/html"
+)
+
+// Where a parameter value shows up on the response
+const (
+	TextReflection      = "text"
+	AttributeReflection = "attribute"
+	UrlReflection       = "url"
+	ScriptReflection    = "script"
+	StyleReflection     = "style"
+	CommentReflection   = "comment"
+	HeaderReflection    = "header"
+	BodyReflection      = "body"
+)
+
+// Appended to canaries to check whether special characters are reflected without encoding
+const reflectionSuffix = `"'<>`
+
+// Attributes whose value is loaded or navigated to as a URL
+var urlAttributes = []string{"href", "src", "action", "formaction", "data", "poster"}
+
+type Reflection struct {
+	Parameter string `json:"parameter"`
+	Context   string `json:"context"`
+
+	// Special characters (quotes or angle brackets) were reflected without encoding
+	Unescaped bool `json:"unescaped"`
+}
+
+type ReflectionProber struct {
+	client  *client.BloodhoundClient
+	limiter *client.RateLimiter
+}
+
+func NewReflectionProber(client *client.BloodhoundClient, limiter *client.RateLimiter) *ReflectionProber {
+	return &ReflectionProber{
+		client:  client,
+		limiter: limiter,
+	}
+}
+
+func ProbeReflections(prober *ReflectionProber, in <-chan Context, out chan<- Context) {
+	defer close(out)
+
+	for context := range in {
+		context.Reflections = prober.Probe(context)
+
+		if len(context.Reflections) != 0 {
+			log.WithFields(log.Fields{
+				"target":      context.Url,
+				"reflections": len(context.Reflections),
+			}).Debug("Found reflected parameters")
+		}
+
+		out <- context
+	}
+}
+
+// Sends a single variant of the target with a unique canary in each query parameter, and finds where each canary is reflected
+func (prober *ReflectionProber) Probe(context Context) []Reflection {
+	parsed, err := url.Parse(context.Url)
+
+	if err != nil || parsed.RawQuery == "" {
+		return nil
+	}
+
+	query := parsed.Query()
+	canaries := make(map[string]string)
+
+	for parameter := range query {
+		canary := "bh" + randomToken()[:10]
+		canaries[parameter] = canary
+		query.Set(parameter, canary+reflectionSuffix)
+	}
+
+	parsed.RawQuery = query.Encode()
+
+	prober.limiter.Wait()
+
+	request, err := http.NewRequest("GET", parsed.String(), nil)
+
+	if err != nil {
+		return nil
+	}
+
+	response, err := prober.client.Do(request)
+
+	if err != nil {
+		log.WithFields(log.Fields{
+			"target": context.Url,
+			"err":    err.Error(),
+		}).Warn("Unable to request reflection probe")
+
+		return nil
+	}
+
+	body, _ := io.ReadAll(response.Body)
+	response.Body.Close()
+
+	return FindReflections(canaries, response.Header, body)
+}
+
+// Finds the contexts each parameter canary shows up on, parameters are listed in name order
+func FindReflections(canaries map[string]string, header http.Header, body []byte) []Reflection {
+	var parameters []string
+	for parameter := range canaries {
+		parameters = append(parameters, parameter)
+	}
+
+	slices.Sort(parameters)
+
+	parseHtml := isHtml(header, body)
+
+	var reflections []Reflection
+
+	for _, parameter := range parameters {
+		canary := canaries[parameter]
+
+		add := func(reflectionContext string, unescaped bool) {
+			for i, reflection := range reflections {
+				if reflection.Parameter == parameter && reflection.Context == reflectionContext {
+					reflections[i].Unescaped = reflection.Unescaped || unescaped
+					return
+				}
+			}
+
+			reflections = append(reflections, Reflection{
+				Parameter: parameter,
+				Context:   reflectionContext,
+				Unescaped: unescaped,
+			})
+		}
+
+		var names []string
+		for name := range header {
+			names = append(names, name)
+		}
+
+		slices.Sort(names)
+
+		for _, name := range names {
+			for _, value := range header.Values(name) {
+				for _, index := range canaryIndexes([]byte(value), canary) {
+					add(HeaderReflection, survives([]byte(value), index+len(canary), "<>"))
+				}
+			}
+		}
+
+		if !bytes.Contains(body, []byte(canary)) {
+			continue
+		}
+
+		if !parseHtml {
+			for _, index := range canaryIndexes(body, canary) {
+				add(BodyReflection, survives(body, index+len(canary), "<>"))
+			}
+
+			continue
+		}
+
+		findHtmlReflections(body, canary, add)
+	}
+
+	return reflections
+}
+
+// Escaping is checked on the raw bytes of each token, since parsing decodes entities. Each occurrence only counts
+// as unescaped when the characters that break out of its own context are reflected without encoding
+func findHtmlReflections(body []byte, canary string, add func(reflectionContext string, unescaped bool)) {
+	tokenizer := html.NewTokenizer(bytes.NewReader(body))
+
+	// Script and style contents are tokenized as raw text after their start tag
+	var rawTag string
+
+	for {
+		tokenType := tokenizer.Next()
+
+		if tokenType == html.ErrorToken {
+			return
+		}
+
+		raw := tokenizer.Raw()
+
+		switch tokenType {
+		case html.TextToken:
+			for _, index := range canaryIndexes(raw, canary) {
+				switch rawTag {
+				case "script":
+					// Closing the script element works anywhere, quotes only break out of strings they delimit
+					unescaped := survives(raw, index+len(canary), "<")
+					if index > 0 && strings.IndexByte("\"'`", raw[index-1]) != -1 {
+						unescaped = unescaped || survives(raw, index+len(canary), string(raw[index-1]))
+					}
+
+					add(ScriptReflection, unescaped)
+				case "style":
+					add(StyleReflection, survives(raw, index+len(canary), "<"))
+				default:
+					add(TextReflection, survives(raw, index+len(canary), "<"))
+				}
+			}
+		case html.CommentToken:
+			for _, index := range canaryIndexes(raw, canary) {
+				add(CommentReflection, survives(raw, index+len(canary), ">"))
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			for _, attr := range rawAttributes(raw) {
+				for _, index := range canaryIndexes(raw[attr.start:attr.end], canary) {
+					// Quoted values need their quote to break out, unquoted values end on the tag end
+					required := ">"
+					if attr.quote != 0 {
+						required = string(attr.quote)
+					}
+
+					add(attributeReflectionContext(html.Attribute{Key: attr.key}), survives(raw, attr.start+index+len(canary), required))
+				}
+			}
+		}
+
+		rawTag = ""
+		if tokenType == html.StartTagToken {
+			if name, _ := tokenizer.TagName(); string(name) == "script" || string(name) == "style" {
+				rawTag = string(name)
+			}
+		}
+	}
+}
+
+// Event handlers run as scripts, and URL attributes may accept javascript: URLs
+func attributeReflectionContext(attr html.Attribute) string {
+	key := strings.ToLower(attr.Key)
+
+	switch {
+	case strings.HasPrefix(key, "on"):
+		return ScriptReflection
+	case slices.Contains(urlAttributes, key):
+		return UrlReflection
+	default:
+		return AttributeReflection
+	}
+}
+
+func canaryIndexes(content []byte, canary string) []int {
+	var indexes []int
+
+	for offset := 0; ; {
+		index := bytes.Index(content[offset:], []byte(canary))
+		if index == -1 {
+			return indexes
+		}
+
+		indexes = append(indexes, offset+index)
+		offset += index + len(canary)
+	}
+}
+
+// Whether every required character of the suffix sent after the canary at position shows up without encoding.
+// Suffix characters are walked in order, skipping HTML entities, JavaScript escapes and percent encoding
+func survives(content []byte, position int, required string) bool {
+	var reflected []byte
+
+	for _, char := range []byte(reflectionSuffix) {
+		if position >= len(content) {
+			break
+		}
+
+		switch content[position] {
+		case char:
+			reflected = append(reflected, char)
+			position++
+		case '&':
+			end := bytes.IndexByte(content[position:], ';')
+			if end == -1 || end > 10 {
+				break
+			}
+
+			position += end + 1
+		case '\\':
+			position += escapeLength(content[position:])
+		case '%':
+			position += 3
+		}
+
+		// Characters that are neither reflected nor encoded were stripped, the next one is checked on the same position
+	}
+
+	for _, char := range []byte(required) {
+		if bytes.IndexByte(reflected, char) == -1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Length of a JavaScript escape sequence (\", \x22, \u0022)
+func escapeLength(content []byte) int {
+	if len(content) < 2 {
+		return len(content)
+	}
+
+	switch content[1] {
+	case 'x':
+		return min(4, len(content))
+	case 'u':
+		return min(6, len(content))
+	default:
+		return 2
+	}
+}
+
+type rawAttribute struct {
+	key   string
+	quote byte
+
+	// Bounds of the raw value on the tag
+	start int
+	end   int
+}
+
+// Attributes of a raw start tag, with the bounds of their values before entities are decoded
+func rawAttributes(raw []byte) []rawAttribute {
+	var attributes []rawAttribute
+
+	isSpace := func(char byte) bool {
+		return char == ' ' || char == '\t' || char == '\n' || char == '\r' || char == '\f'
+	}
+
+	// Skip "<" and the tag name
+	position := 1
+	for position < len(raw) && !isSpace(raw[position]) && raw[position] != '>' && raw[position] != '/' {
+		position++
+	}
+
+	for position < len(raw) {
+		for position < len(raw) && (isSpace(raw[position]) || raw[position] == '/') {
+			position++
+		}
+
+		if position >= len(raw) || raw[position] == '>' {
+			break
+		}
+
+		keyStart := position
+		for position < len(raw) && !isSpace(raw[position]) && raw[position] != '=' && raw[position] != '>' && raw[position] != '/' {
+			position++
+		}
+
+		attribute := rawAttribute{key: string(raw[keyStart:position])}
+
+		for position < len(raw) && isSpace(raw[position]) {
+			position++
+		}
+
+		if position >= len(raw) || raw[position] != '=' {
+			continue
+		}
+
+		position++
+		for position < len(raw) && isSpace(raw[position]) {
+			position++
+		}
+
+		if position < len(raw) && (raw[position] == '"' || raw[position] == '\'') {
+			attribute.quote = raw[position]
+			attribute.start = position + 1
+
+			end := bytes.IndexByte(raw[attribute.start:], attribute.quote)
+			if end == -1 {
+				end = len(raw) - attribute.start
+			}
+
+			attribute.end = attribute.start + end
+			position = attribute.end + 1
+		} else {
+			attribute.start = position
+			for position < len(raw) && !isSpace(raw[position]) && raw[position] != '>' {
+				position++
+			}
+
+			attribute.end = position
+		}
+
+		attributes = append(attributes, attribute)
+	}
+
+	return attributes
+}
+
+// Responses without content type are parsed as HTML, like browsers sniffing them would
+func isHtml(header http.Header, body []byte) bool {
+	contentType := strings.ToLower(header.Get("Content-Type"))
+
+	if contentType == "" {
+		trimmed := bytes.TrimSpace(body)
+		return !bytes.HasPrefix(trimmed, []byte("{")) && !bytes.HasPrefix(trimmed, []byte("["))
+	}
+
+	return strings.Contains(contentType, "html")
+}
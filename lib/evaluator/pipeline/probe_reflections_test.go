@@ -0,0 +1,108 @@
+package pipeline
+
+import (
+	"bloodhound/lib/client"
+	"fmt"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProbeReflections(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("X-Search", query.Get("q"))
+
+		fmt.Fprintf(w, `<html>
+			<body>
+				<p>Results for %s</p>
+				<a href="/search?page=%s">Next</a>
+				<script>var lang = "%s";</script>
+			</body>
+			</html>`, html.EscapeString(query.Get("q")), html.EscapeString(query.Get("page")), query.Get("lang"))
+	}))
+	defer server.Close()
+
+	limiter := client.NewRateLimiter(100)
+	defer limiter.Stop()
+
+	prober := NewReflectionProber(client.NewClient(client.ClientConfig{}), limiter)
+
+	t.Run("parameters reflected on multiple contexts", func(t *testing.T) {
+		reflections := prober.Probe(NewContext(server.URL + "/search?q=shoes&page=2&lang=en&sort=asc"))
+
+		expected := []Reflection{
+			{Parameter: "lang", Context: ScriptReflection, Unescaped: true},
+			{Parameter: "page", Context: UrlReflection, Unescaped: false},
+			{Parameter: "q", Context: HeaderReflection, Unescaped: true},
+			{Parameter: "q", Context: TextReflection, Unescaped: false},
+		}
+
+		if len(expected) != len(reflections) {
+			t.Fatalf("Probe; want %+v; got %+v", expected, reflections)
+		}
+
+		for i := range expected {
+			if expected[i] != reflections[i] {
+				t.Errorf("Probe; want %+v; got %+v", expected[i], reflections[i])
+			}
+		}
+	})
+
+	t.Run("target without parameters is not probed", func(t *testing.T) {
+		if reflections := prober.Probe(NewContext(server.URL + "/search")); reflections != nil {
+			t.Errorf("Probe; want no reflections; got %+v", reflections)
+		}
+	})
+}
+
+func TestFindReflections(t *testing.T) {
+	t.Run("plain text body", func(t *testing.T) {
+		header := http.Header{"Content-Type": []string{"text/plain"}}
+		body := []byte(`No results for bhcanary"'<>`)
+
+		reflections := FindReflections(map[string]string{"query": "bhcanary"}, header, body)
+
+		if len(reflections) != 1 || reflections[0] != (Reflection{Parameter: "query", Context: BodyReflection, Unescaped: true}) {
+			t.Errorf("FindReflections; want unescaped body reflection; got %+v", reflections)
+		}
+	})
+
+	t.Run("event handler attribute", func(t *testing.T) {
+		body := []byte(`<button onclick="track('bhcanary&quot;&#39;&lt;&gt;')">Buy</button>`)
+
+		reflections := FindReflections(map[string]string{"ref": "bhcanary"}, http.Header{}, body)
+
+		if len(reflections) != 1 || reflections[0] != (Reflection{Parameter: "ref", Context: ScriptReflection, Unescaped: false}) {
+			t.Errorf("FindReflections; want escaped script reflection; got %+v", reflections)
+		}
+	})
+
+	t.Run("escaping is checked on each occurrence", func(t *testing.T) {
+		body := []byte(`<p>Results for bhcanary&quot;&#39;&lt;&gt;</p><script>var state = {"q": "bhcanary\"'<>"}</script>`)
+
+		reflections := FindReflections(map[string]string{"q": "bhcanary"}, http.Header{"Content-Type": []string{"text/html"}}, body)
+
+		expected := []Reflection{
+			{Parameter: "q", Context: TextReflection, Unescaped: false},
+			{Parameter: "q", Context: ScriptReflection, Unescaped: true},
+		}
+
+		if len(reflections) != 2 || reflections[0] != expected[0] || reflections[1] != expected[1] {
+			t.Errorf("FindReflections; want %+v; got %+v", expected, reflections)
+		}
+	})
+
+	t.Run("attribute needs its own quote", func(t *testing.T) {
+		body := []byte(`<input name="q" value="bhcanary&quot;'&lt;&gt;">`)
+
+		reflections := FindReflections(map[string]string{"q": "bhcanary"}, http.Header{}, body)
+
+		if len(reflections) != 1 || reflections[0] != (Reflection{Parameter: "q", Context: AttributeReflection, Unescaped: false}) {
+			t.Errorf("FindReflections; want escaped attribute reflection; got %+v", reflections)
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package pipeline
+
+import (
+	"bloodhound/lib/archive"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Replaces RetrieveResource when evaluating saved responses, so no request is sent to the target
+func ReplayResource(source *archive.Archive, in <-chan Context, out chan<- Context) {
+	defer close(out)
+
+	for context := range in {
+		context, found := ReplayContext(source, context)
+
+		if !found {
+			log.WithFields(log.Fields{
+				"target": context.Url,
+			}).Warn("Resource not found on archive: Skipping")
+
+			continue
+		}
+
+		if context.Response.StatusCode != http.StatusOK && context.IsApiEndpoint() {
+			log.WithFields(log.Fields{
+				"target":     context.Url,
+				"statusCode": context.Response.StatusCode,
+			}).Debug("Archived resource has non-OK status: Evaluating known API endpoint anyway")
+		} else if context.Response.StatusCode != http.StatusOK {
+			log.WithFields(log.Fields{
+				"target":     context.Url,
+				"statusCode": context.Response.StatusCode,
+			}).Warn("Archived resource has non-OK status: Content evaluation will not be available")
+
+			continue
+		}
+
+		log.WithFields(log.Fields{
+			"target": context.Url,
+		}).Trace("Finished replaying resource")
+
+		out <- context
+	}
+}
+
+// Stores the archived response of the context target on it, if there is one
+func ReplayContext(source *archive.Archive, context Context) (Context, bool) {
+	entry, found := source.Get(context.Url)
+
+	if !found {
+		return context, false
+	}
+
+	context.SetResponse(&Response{
+		Method:        entry.Method,
+		RequestHeader: entry.RequestHeader,
+		StatusCode:    entry.StatusCode,
+		Header:        entry.Header,
+		Body:          entry.Body,
+	})
+
+	return context, true
+}
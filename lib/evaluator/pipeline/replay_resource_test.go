@@ -0,0 +1,52 @@
+package pipeline
+
+import (
+	"bloodhound/lib/apispec"
+	"bloodhound/lib/archive"
+	"net/http"
+	"testing"
+)
+
+func TestReplayResource(t *testing.T) {
+	dir := t.TempDir()
+	writer, err := archive.NewWriter(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, entry := range []*archive.Entry{
+		{Url: "http://localhost/admin", Method: http.MethodGet, StatusCode: http.StatusForbidden},
+		{Url: "http://localhost/api/users", Method: http.MethodGet, StatusCode: http.StatusUnauthorized, Body: []byte(`{"error":"unauthorized"}`)},
+	} {
+		if err := writer.Write(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writer.Close()
+
+	source, err := archive.Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	endpoint := NewContext("http://localhost/api/users")
+	endpoint.Endpoint = &apispec.Endpoint{}
+
+	in := make(chan Context, 2)
+	out := make(chan Context, 2)
+	in <- NewContext("http://localhost/admin")
+	in <- endpoint
+	close(in)
+
+	ReplayResource(source, in, out)
+
+	var urls []string
+	for context := range out {
+		urls = append(urls, context.Url)
+	}
+
+	if len(urls) != 1 || urls[0] != "http://localhost/api/users" {
+		t.Errorf("ReplayResource; want only the non-OK API endpoint; got %v", urls)
+	}
+}
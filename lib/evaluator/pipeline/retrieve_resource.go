@@ -1,40 +1,21 @@
 package pipeline
 
 import (
+	"bloodhound/lib/archive"
 	"bloodhound/lib/client"
-	"bytes"
 	"io"
 	"net/http"
 	"sync"
-	"time"
 
 	"github.com/bradhe/stopwatch"
-	"golang.org/x/net/html"
 
 	log "github.com/sirupsen/logrus"
 )
 
-func RetrieveResource(clientConfig client.ClientConfig, in <-chan Context, out chan<- Context) {
+// Responses are stored before filtering by status, when a store is given, so every request and response pair is kept
+func RetrieveResource(clientConfig client.ClientConfig, limiter *client.RateLimiter, store *archive.Writer, in <-chan Context, out chan<- Context) {
 	var wg sync.WaitGroup
 
-	/*
-		Each request needs to retrieve a token to be executed,
-			and tokens are generated into the channel based on the rate limiting.
-
-		Meaning that every available goroutine will have to wait and respect
-			the configured rate limiting
-	*/
-	tokenChannel := make(chan struct{}, clientConfig.Rate)
-	go func() {
-		ticker := time.NewTicker(time.Second / time.Duration(clientConfig.Rate))
-		defer ticker.Stop()
-
-		for {
-			<-ticker.C
-			tokenChannel <- struct{}{}
-		}
-	}()
-
 	// TODO: Make this a configuration
 	for range 10 {
 		wg.Add(1)
@@ -45,7 +26,7 @@ func RetrieveResource(clientConfig client.ClientConfig, in <-chan Context, out c
 
 			for context := range in {
 				// Wait until request is allowed by rate limiter
-				<-tokenChannel
+				limiter.Wait()
 
 				watch := stopwatch.Start()
 
@@ -53,18 +34,7 @@ func RetrieveResource(clientConfig client.ClientConfig, in <-chan Context, out c
 					"target": context.Url,
 				}).Trace("Requesting resource")
 
-				request, err := http.NewRequest("GET", context.Url, nil)
-
-				if err != nil {
-					log.WithFields(log.Fields{
-						"target": context.Url,
-						"err":    err.Error(),
-					}).Error("Unable to create HTTP request: Skipping")
-
-					continue
-				}
-
-				response, err := client.Do(request)
+				context, err := FetchResource(client, context)
 
 				if err != nil {
 					log.WithFields(log.Fields{
@@ -77,13 +47,29 @@ func RetrieveResource(clientConfig client.ClientConfig, in <-chan Context, out c
 
 				watch.Stop()
 
-				if response.StatusCode == http.StatusTooManyRequests {
+				StoreResponse(store, context)
+
+				if context.Response.StatusCode == http.StatusTooManyRequests {
 					log.Fatal(`Requests are being limited by target, evaluation received HTTP status 429 Too Many Requests.
 						Try running the command again with adjusted request rate settings.`)
-				} else if response.StatusCode != http.StatusOK {
+				} else if context.Response.StatusCode != http.StatusOK && context.IsApiEndpoint() {
+					log.WithFields(log.Fields{
+						"target":     context.Url,
+						"statusCode": context.Response.StatusCode,
+					}).Debug("Resource returned non-OK status: Evaluating known API endpoint anyway")
+
+					out <- context
+				} else if context.Response.StatusCode != http.StatusOK && context.IsDiscoveryHit() {
+					log.WithFields(log.Fields{
+						"target":     context.Url,
+						"statusCode": context.Response.StatusCode,
+					}).Debug("Resource returned non-OK status: Evaluating discovered resource anyway")
+
+					out <- context
+				} else if context.Response.StatusCode != http.StatusOK {
 					log.WithFields(log.Fields{
 						"target":     context.Url,
-						"statusCode": response.StatusCode,
+						"statusCode": context.Response.StatusCode,
 						"duration":   watch.Milliseconds(),
 					}).Warn("Resource returned non-OK status: Content evaluation will not be available")
 				} else {
@@ -92,21 +78,6 @@ func RetrieveResource(clientConfig client.ClientConfig, in <-chan Context, out c
 						"duration": watch.Milliseconds(),
 					}).Trace("Finished requesting resource")
 
-					// TODO: Check if html.Parse is guaranteed to always read the entire reader
-					body, err := io.ReadAll(response.Body)
-					response.Body.Close()
-
-					if err != nil {
-						log.WithFields(log.Fields{
-							"target": context.Url,
-							"err":    err,
-						}).Error("Unable to read response body")
-					}
-
-					bodyReader := bytes.NewReader(body)
-					document, _ := html.Parse(bodyReader)
-					context.Content = document
-
 					out <- context
 				}
 			}
@@ -118,3 +89,46 @@ func RetrieveResource(clientConfig client.ClientConfig, in <-chan Context, out c
 		close(out)
 	}()
 }
+
+// Requests the target of a context, and stores the response and the parsed content of successful requests on it
+func FetchResource(client *client.BloodhoundClient, context Context) (Context, error) {
+	request, err := http.NewRequest("GET", context.Url, nil)
+
+	if err != nil {
+		return context, err
+	}
+
+	var response *http.Response
+
+	// Following redirects would evaluate another resource than the one that matched discovery
+	if context.IsDiscoveryHit() {
+		response, err = client.DoWithoutRedirects(request)
+	} else {
+		response, err = client.Do(request)
+	}
+
+	if err != nil {
+		return context, err
+	}
+
+	// TODO: Check if html.Parse is guaranteed to always read the entire reader
+	body, err := io.ReadAll(response.Body)
+	response.Body.Close()
+
+	if err != nil {
+		log.WithFields(log.Fields{
+			"target": context.Url,
+			"err":    err,
+		}).Error("Unable to read response body")
+	}
+
+	context.SetResponse(&Response{
+		Method:        request.Method,
+		RequestHeader: request.Header,
+		StatusCode:    response.StatusCode,
+		Header:        response.Header,
+		Body:          body,
+	})
+
+	return context, nil
+}
@@ -0,0 +1,73 @@
+package pipeline
+
+import (
+	"bloodhound/lib/client"
+	"bloodhound/lib/discovery"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRetrieveResource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/admin":
+			w.WriteHeader(http.StatusForbidden)
+		case "/old":
+			http.Redirect(w, r, "/", http.StatusMovedPermanently)
+		default:
+			w.Write([]byte("<html><body>home</body></html>"))
+		}
+	}))
+	defer server.Close()
+
+	limiter := client.NewRateLimiter(100)
+	defer limiter.Stop()
+
+	retrieve := func(contexts ...Context) map[string]int {
+		in := make(chan Context, len(contexts))
+		out := make(chan Context)
+
+		for _, context := range contexts {
+			in <- context
+		}
+		close(in)
+
+		go RetrieveResource(client.ClientConfig{}, limiter, nil, in, out)
+
+		statuses := make(map[string]int)
+		for context := range out {
+			statuses[context.Url] = context.Response.StatusCode
+		}
+
+		return statuses
+	}
+
+	hit := func(targetUrl string, statusCode int) Context {
+		context := NewContext(targetUrl)
+		context.DiscoveryHit = &discovery.ResponseStats{StatusCode: statusCode}
+		return context
+	}
+
+	t.Run("non-OK input is skipped", func(t *testing.T) {
+		statuses := retrieve(NewContext(server.URL + "/admin"))
+
+		if len(statuses) != 0 {
+			t.Errorf("RetrieveResource; want no results; got %v", statuses)
+		}
+	})
+
+	t.Run("non-OK discovery hits are evaluated without following redirects", func(t *testing.T) {
+		statuses := retrieve(hit(server.URL+"/admin", http.StatusForbidden), hit(server.URL+"/old", http.StatusMovedPermanently))
+		expected := map[string]int{
+			server.URL + "/admin": http.StatusForbidden,
+			server.URL + "/old":   http.StatusMovedPermanently,
+		}
+
+		for targetUrl, statusCode := range expected {
+			if statuses[targetUrl] != statusCode {
+				t.Errorf("RetrieveResource %s; want %d; got %d", targetUrl, statusCode, statuses[targetUrl])
+			}
+		}
+	})
+}
@@ -0,0 +1,189 @@
+package pipeline
+
+import (
+	"bloodhound/lib/client"
+	"bloodhound/lib/rules"
+	"bloodhound/lib/simhash"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type SoftNotFoundMode string
+
+const (
+	SoftNotFoundOff      SoftNotFoundMode = "off"
+	SoftNotFoundPenalize SoftNotFoundMode = "penalize"
+	SoftNotFoundDrop     SoftNotFoundMode = "drop"
+)
+
+func ParseSoftNotFoundMode(mode string) (SoftNotFoundMode, error) {
+	switch SoftNotFoundMode(mode) {
+	case SoftNotFoundOff, SoftNotFoundPenalize, SoftNotFoundDrop:
+		return SoftNotFoundMode(mode), nil
+	default:
+		return SoftNotFoundOff, fmt.Errorf("unknown soft 404 mode: %s", mode)
+	}
+}
+
+// Matched by penalized soft 404 responses, so the penalty is visible together with the other matched rules
+var SoftNotFoundRule = rules.Rule{
+	Name:        "Soft 404 response",
+	Description: "Response looks like the response of the host for paths that don't exist",
+	Category:    "soft-404",
+	Multiplier:  0.1,
+}
+
+type notFoundFingerprint struct {
+	StatusCode int
+	Length     int
+	Simhash    uint64
+}
+
+// Requested path is removed from the body first, since error pages often reflect it
+func newNotFoundFingerprint(statusCode int, path string, body []byte) notFoundFingerprint {
+	if path != "" && path != "/" {
+		body = bytes.ReplaceAll(body, []byte(path), nil)
+	}
+
+	return notFoundFingerprint{
+		StatusCode: statusCode,
+		Length:     len(body),
+		Simhash:    simhash.Text(body),
+	}
+}
+
+func (fingerprint notFoundFingerprint) matches(other notFoundFingerprint) bool {
+	return fingerprint.StatusCode == other.StatusCode &&
+		(fingerprint.Length == other.Length || simhash.Similar(fingerprint.Simhash, other.Simhash))
+}
+
+// Many hosts answer every path with 200, serving an error or home page for paths that don't exist.
+// Before the first target of a host is evaluated, random paths are requested to fingerprint how the
+// host answers "not found", and responses matching it are marked as soft 404
+type SoftNotFoundDetector struct {
+	client  *client.BloodhoundClient
+	limiter *client.RateLimiter
+	hosts   map[string][]notFoundFingerprint
+}
+
+func NewSoftNotFoundDetector(client *client.BloodhoundClient, limiter *client.RateLimiter) *SoftNotFoundDetector {
+	return &SoftNotFoundDetector{
+		client:  client,
+		limiter: limiter,
+		hosts:   make(map[string][]notFoundFingerprint),
+	}
+}
+
+func (detector *SoftNotFoundDetector) IsSoftNotFound(context Context) bool {
+	if context.Response == nil {
+		return false
+	}
+
+	parsed, err := url.Parse(context.Url)
+
+	if err != nil {
+		return false
+	}
+
+	response := newNotFoundFingerprint(context.Response.StatusCode, parsed.Path, context.Response.Body)
+
+	for _, fingerprint := range detector.fingerprints(context.Url) {
+		if fingerprint.matches(response) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Fingerprints are only requested once per host, and only OK responses are kept since others are never evaluated.
+// Redirects aren't followed, otherwise hosts redirecting unknown paths would fingerprint their landing or login page
+func (detector *SoftNotFoundDetector) fingerprints(targetUrl string) []notFoundFingerprint {
+	parsed, err := url.Parse(targetUrl)
+
+	if err != nil {
+		return nil
+	}
+
+	host := parsed.Scheme + "://" + parsed.Host
+
+	if fingerprints, found := detector.hosts[host]; found {
+		return fingerprints
+	}
+
+	var fingerprints []notFoundFingerprint
+
+	for _, path := range []string{"/" + randomToken(), "/" + randomToken() + ".html", "/" + randomToken() + "/" + randomToken()} {
+		detector.limiter.Wait()
+
+		request, err := http.NewRequest("GET", host+path, nil)
+
+		if err != nil {
+			continue
+		}
+
+		response, err := detector.client.DoWithoutRedirects(request)
+
+		if err != nil {
+			log.WithFields(log.Fields{
+				"host": host,
+				"err":  err.Error(),
+			}).Debug("Unable to request random path for soft 404 detection")
+
+			continue
+		}
+
+		body, _ := io.ReadAll(response.Body)
+		response.Body.Close()
+
+		if response.StatusCode == http.StatusOK {
+			fingerprints = append(fingerprints, newNotFoundFingerprint(response.StatusCode, path, body))
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"host":         host,
+		"fingerprints": len(fingerprints),
+	}).Debug("Finished soft 404 fingerprinting")
+
+	detector.hosts[host] = fingerprints
+
+	return fingerprints
+}
+
+func DetectSoftNotFound(detector *SoftNotFoundDetector, mode SoftNotFoundMode, in <-chan Context, out chan<- Context) {
+	defer close(out)
+
+	for context := range in {
+		if detector.IsSoftNotFound(context) {
+			context.SoftNotFound = true
+
+			log.WithFields(log.Fields{
+				"target": context.Url,
+				"mode":   mode,
+			}).Debug("Resource matches the soft 404 fingerprint of its host")
+
+			if mode == SoftNotFoundDrop {
+				continue
+			}
+
+			context.AddMatches([]Match{NewMatch(SoftNotFoundRule, "response", "")})
+		}
+
+		out <- context
+	}
+}
+
+func randomToken() string {
+	data := make([]byte, 12)
+	rand.Read(data)
+
+	return hex.EncodeToString(data)
+}
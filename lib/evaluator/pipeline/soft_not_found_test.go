@@ -0,0 +1,72 @@
+package pipeline
+
+import (
+	"bloodhound/lib/client"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSoftNotFoundDetector(t *testing.T) {
+	assert := func(t testing.TB, expected bool, actual bool) {
+		t.Helper()
+		if expected != actual {
+			t.Errorf("IsSoftNotFound; want %v; got %v", expected, actual)
+		}
+	}
+
+	newContext := func(targetUrl string, body string) Context {
+		context := NewContext(targetUrl)
+		context.Response = &Response{StatusCode: http.StatusOK, Body: []byte(body)}
+		return context
+	}
+
+	catchAll := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/admin" {
+			fmt.Fprint(w, "<html><body><form><input name=\"username\"><input type=\"password\"></form></body></html>")
+			return
+		}
+
+		fmt.Fprintf(w, "<html><body><h1>Page not found</h1><p>We could not find %s, go back to the home page</p></body></html>", r.URL.Path)
+	}))
+	defer catchAll.Close()
+
+	redirecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			fmt.Fprint(w, "<html><body><form><input name=\"username\"><input type=\"password\"></form></body></html>")
+			return
+		}
+
+		http.Redirect(w, r, "/login", http.StatusFound)
+	}))
+	defer redirecting.Close()
+
+	strict := httptest.NewServer(http.NotFoundHandler())
+	defer strict.Close()
+
+	limiter := client.NewRateLimiter(100)
+	defer limiter.Stop()
+
+	detector := NewSoftNotFoundDetector(client.NewClient(client.ClientConfig{}), limiter)
+
+	t.Run("catch all page is soft 404", func(t *testing.T) {
+		context := newContext(catchAll.URL+"/backup", "<html><body><h1>Page not found</h1><p>We could not find /backup, go back to the home page</p></body></html>")
+		assert(t, true, detector.IsSoftNotFound(context))
+	})
+
+	t.Run("real page is not soft 404", func(t *testing.T) {
+		context := newContext(catchAll.URL+"/admin", "<html><body><form><input name=\"username\"><input type=\"password\"></form></body></html>")
+		assert(t, false, detector.IsSoftNotFound(context))
+	})
+
+	t.Run("redirect target of unknown paths is not soft 404", func(t *testing.T) {
+		context := newContext(redirecting.URL+"/login", "<html><body><form><input name=\"username\"><input type=\"password\"></form></body></html>")
+		assert(t, false, detector.IsSoftNotFound(context))
+	})
+
+	t.Run("host answering not found is never soft 404", func(t *testing.T) {
+		context := newContext(strict.URL+"/index", "404 page not found\n")
+		assert(t, false, detector.IsSoftNotFound(context))
+	})
+}
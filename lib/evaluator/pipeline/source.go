@@ -0,0 +1,77 @@
+package pipeline
+
+import "fmt"
+
+// How a target was found, recon sources use the kinds of the recon package (e.g. robots-disallow)
+const (
+	InputSource      = "input"
+	ReplaySource     = "replay"
+	LinkSource       = "link"
+	ScriptSource     = "script"
+	BruteforceSource = "bruteforce"
+	WellKnownSource  = "well-known"
+	SpecSource       = "spec"
+)
+
+// Sources of discovered targets point to the source of the page they were found on, forming a chain back to the input
+type Source struct {
+	Kind string `json:"kind"`
+
+	// Input file, page, spec or recon file the target was found on
+	Url string `json:"url,omitempty"`
+
+	// Where on it the target was found (e.g. line 3, a[href])
+	Via string `json:"via,omitempty"`
+
+	From *Source `json:"from,omitempty"`
+}
+
+func NewSource(kind string, url string, via string) Source {
+	return Source{
+		Kind: kind,
+		Url:  url,
+		Via:  via,
+	}
+}
+
+// Source of a target found on a line of an input file
+func NewInputSource(file string, line int) Source {
+	return NewSource(InputSource, file, fmt.Sprintf("line %d", line))
+}
+
+// Source of a link found on the page of the context, chained to how the page itself was found
+func (context *Context) LinkSource(link Link) Source {
+	kind := LinkSource
+	if link.Via == "script" {
+		kind = ScriptSource
+	}
+
+	source := NewSource(kind, context.Url, link.Via)
+
+	if len(context.Sources) != 0 {
+		source.From = &context.Sources[0]
+	}
+
+	return source
+}
+
+// Records another way the target was found, sources equal to a known one are ignored
+func (context *Context) AddSource(source Source) {
+	for _, known := range context.Sources {
+		if known.Kind == source.Kind && known.Url == source.Url && known.Via == source.Via {
+			return
+		}
+	}
+
+	context.Sources = append(context.Sources, source)
+}
+
+// Kinds of every source of the target, in order of discovery
+func (context *Context) SourceKinds() []string {
+	var kinds []string
+	for _, source := range context.Sources {
+		kinds = append(kinds, source.Kind)
+	}
+
+	return kinds
+}
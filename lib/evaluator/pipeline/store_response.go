@@ -0,0 +1,30 @@
+package pipeline
+
+import (
+	"bloodhound/lib/archive"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Saves the response of a context, so pages can be reviewed without requesting them again
+func StoreResponse(writer *archive.Writer, context Context) {
+	if writer == nil || context.Response == nil {
+		return
+	}
+
+	err := writer.Write(&archive.Entry{
+		Url:           context.Url,
+		Method:        context.Response.Method,
+		RequestHeader: context.Response.RequestHeader,
+		StatusCode:    context.Response.StatusCode,
+		Header:        context.Response.Header,
+		Body:          context.Response.Body,
+	})
+
+	if err != nil {
+		log.WithFields(log.Fields{
+			"target": context.Url,
+			"err":    err.Error(),
+		}).Error("Unable to store response")
+	}
+}
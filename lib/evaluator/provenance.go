@@ -0,0 +1,68 @@
+package evaluator
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/rules"
+	"slices"
+)
+
+func EvaluateProvenance(sources []pipeline.Source, ruleList []rules.Rule) EvaluationResult {
+	result := DefaultEvaluationResult()
+
+	if len(sources) == 0 {
+		return result
+	}
+
+	for _, rule := range ruleList {
+		if rule.Level != rules.ProvenanceLevel || rule.Content.Provenance == nil {
+			continue
+		}
+
+		source, found := findSource(sources, rule.Content.Provenance)
+
+		if !found {
+			continue
+		}
+
+		if rule.Remove {
+			return NewEvaluationResult(0, rule.Remove)
+		}
+
+		result.addMatch(pipeline.NewMatch(rule, "provenance", describeSource(source)))
+	}
+
+	return result
+}
+
+// First source of any of the kinds of the condition, when only those kinds are allowed every source must be one of them
+func findSource(sources []pipeline.Source, condition *rules.ProvenanceCondition) (pipeline.Source, bool) {
+	var found []pipeline.Source
+
+	for _, source := range sources {
+		if slices.Contains(condition.Sources, source.Kind) {
+			found = append(found, source)
+		} else if condition.Only {
+			return pipeline.Source{}, false
+		}
+	}
+
+	if len(found) == 0 {
+		return pipeline.Source{}, false
+	}
+
+	return found[0], true
+}
+
+func describeSource(source pipeline.Source) string {
+	description := source.Kind
+
+	if source.Url != "" {
+		description += " on " + source.Url
+	}
+
+	if source.Via != "" {
+		description += " (" + source.Via + ")"
+	}
+
+	return description
+}
@@ -0,0 +1,97 @@
+package evaluator
+
+import (
+	"bloodhound/lib/client"
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/recon"
+	"bloodhound/lib/rules"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEvaluateProvenance(t *testing.T) {
+	assert := func(t testing.TB, expected EvaluationResult, actual EvaluationResult) {
+		t.Helper()
+		if expected.Score != actual.Score || expected.Remove != actual.Remove {
+			t.Errorf("EvaluateProvenance; want %v; got %v", expected, actual)
+		}
+	}
+
+	newRule := func(condition rules.ProvenanceCondition, value float64) []rules.Rule {
+		return []rules.Rule{rules.NewRule("Provenance rule", rules.ProvenanceLevel, value, false, rules.RuleContent{Provenance: &condition})}
+	}
+
+	input := pipeline.NewInputSource("input.txt", 3)
+	disallowed := pipeline.NewSource(recon.RobotsDisallowSource, "http://localhost/robots.txt", "")
+	script := pipeline.NewSource(pipeline.ScriptSource, "http://localhost/app", "script")
+	link := pipeline.NewSource(pipeline.LinkSource, "http://localhost/", "a[href]")
+
+	t.Run("any source", func(t *testing.T) {
+		rule := newRule(rules.ProvenanceCondition{Sources: []string{recon.RobotsDisallowSource}}, 5)
+
+		assert(t, NewEvaluationResult(5, false), EvaluateProvenance([]pipeline.Source{input, disallowed}, rule))
+		assert(t, DefaultEvaluationResult(), EvaluateProvenance([]pipeline.Source{input}, rule))
+		assert(t, DefaultEvaluationResult(), EvaluateProvenance(nil, rule))
+	})
+
+	t.Run("only referenced from scripts", func(t *testing.T) {
+		rule := newRule(rules.ProvenanceCondition{Sources: []string{pipeline.ScriptSource}, Only: true}, 3)
+
+		assert(t, NewEvaluationResult(3, false), EvaluateProvenance([]pipeline.Source{script}, rule))
+		assert(t, DefaultEvaluationResult(), EvaluateProvenance([]pipeline.Source{script, link}, rule))
+	})
+
+	t.Run("match describes the source", func(t *testing.T) {
+		rule := newRule(rules.ProvenanceCondition{Sources: []string{pipeline.LinkSource}}, 1)
+		evaluation := EvaluateProvenance([]pipeline.Source{script, link}, rule)
+
+		expected := "link on http://localhost/ (a[href])"
+		if len(evaluation.Matches) != 1 || evaluation.Matches[0].Snippet != expected {
+			t.Errorf("EvaluateProvenance; want snippet %q; got %v", expected, evaluation.Matches)
+		}
+	})
+}
+
+func TestEvaluateLinkSources(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			fmt.Fprint(w, `<html><body><a href="/about">About</a><script>fetch("/api/items")</script></body></html>`)
+		case "/about":
+			fmt.Fprint(w, `<html><body><a href="/api/items">Items</a></body></html>`)
+		case "/api/items":
+			fmt.Fprint(w, `[{"id": 1}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	condition := rules.ProvenanceCondition{Sources: []string{pipeline.ScriptSource}, Only: true}
+	ruleset := &rules.Ruleset{Rules: []rules.Rule{
+		rules.NewRule("Only referenced from scripts?", rules.ProvenanceLevel, 3, false, rules.RuleContent{Provenance: &condition}),
+	}}
+
+	// The endpoint is found on a script first, and linked by a page of the same wave
+	results := Evaluate([]pipeline.Context{pipeline.NewContext(server.URL + "/")}, ruleset, Config{Client: client.ClientConfig{Rate: 1000}, Depth: 1})
+
+	for _, result := range results {
+		if result.Url != server.URL+"/api/items" {
+			continue
+		}
+
+		if kinds := result.SourceKinds(); len(kinds) != 2 || kinds[0] != pipeline.ScriptSource || kinds[1] != pipeline.LinkSource {
+			t.Errorf("Evaluate; want script and link sources; got %v", kinds)
+		}
+
+		if result.Score != 0 {
+			t.Errorf("Evaluate; want linked endpoint not scored as script only; got %v", result.Score)
+		}
+
+		return
+	}
+
+	t.Errorf("Evaluate; want %s/api/items on results; got %+v", server.URL, results)
+}
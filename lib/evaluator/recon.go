@@ -0,0 +1,39 @@
+package evaluator
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/recon"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Reads the recon files once per host of the targets, and adds the in-scope URLs found on them as targets.
+// Recon sources are added to every target found, including targets that were already on the list
+func HarvestRecon(harvester *recon.Harvester, contexts []pipeline.Context, scope *Scope) []pipeline.Context {
+	targets := newTargetList(contexts)
+
+	for _, origin := range origins(contexts) {
+		added := 0
+
+		for _, entry := range harvester.Harvest(origin, scope.Contains) {
+			if !scope.Contains(entry.Url) {
+				continue
+			}
+
+			context, isNew := targets.add(entry.Url)
+
+			context.AddSource(pipeline.NewSource(entry.Source, entry.File, ""))
+
+			if isNew {
+				added++
+			}
+		}
+
+		log.WithFields(log.Fields{
+			"origin": origin,
+			"added":  added,
+		}).Info("Finished harvesting recon files")
+	}
+
+	return targets.contexts
+}
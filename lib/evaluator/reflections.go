@@ -0,0 +1,55 @@
+package evaluator
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/rules"
+	"strings"
+)
+
+func EvaluateReflections(reflections []pipeline.Reflection, ruleList []rules.Rule) EvaluationResult {
+	result := DefaultEvaluationResult()
+
+	for _, rule := range ruleList {
+		if rule.Level != rules.ReflectionLevel || rule.Content.Reflection == nil {
+			continue
+		}
+
+		for _, reflection := range reflections {
+			if !reflectionMatchesCondition(&reflection, rule.Content.Reflection) {
+				continue
+			}
+
+			if rule.Remove {
+				return NewEvaluationResult(0, rule.Remove)
+			}
+
+			result.addMatch(pipeline.NewMatch(rule, "reflection", describeReflection(&reflection)))
+
+			break
+		}
+	}
+
+	return result
+}
+
+func reflectionMatchesCondition(reflection *pipeline.Reflection, condition *rules.ReflectionCondition) bool {
+	if condition.Context != "" && !strings.EqualFold(condition.Context, reflection.Context) {
+		return false
+	}
+
+	if condition.Unescaped != nil && *condition.Unescaped != reflection.Unescaped {
+		return false
+	}
+
+	return true
+}
+
+func describeReflection(reflection *pipeline.Reflection) string {
+	description := "parameter " + reflection.Parameter + " in " + reflection.Context
+
+	if reflection.Unescaped {
+		description += " (unescaped)"
+	}
+
+	return description
+}
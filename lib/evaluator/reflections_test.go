@@ -0,0 +1,49 @@
+package evaluator
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/rules"
+	"testing"
+)
+
+func TestEvaluateReflections(t *testing.T) {
+	reflections := []pipeline.Reflection{
+		{Parameter: "q", Context: pipeline.TextReflection, Unescaped: false},
+		{Parameter: "lang", Context: pipeline.ScriptReflection, Unescaped: true},
+	}
+
+	assert := func(t testing.TB, expected EvaluationResult, actual EvaluationResult) {
+		t.Helper()
+		if expected.Score != actual.Score || expected.Remove != actual.Remove {
+			t.Errorf("EvaluateReflections; want %v; got %v", expected, actual)
+		}
+	}
+
+	newRule := func(condition rules.ReflectionCondition, value float64) rules.Rule {
+		return rules.NewRule("Reflection rule", rules.ReflectionLevel, value, false, rules.RuleContent{Reflection: &condition})
+	}
+
+	unescaped := true
+
+	t.Run("any reflection", func(t *testing.T) {
+		assert(t, NewEvaluationResult(1, false), EvaluateReflections(reflections, []rules.Rule{newRule(rules.ReflectionCondition{}, 1)}))
+	})
+
+	t.Run("reflection on context", func(t *testing.T) {
+		ruleList := []rules.Rule{
+			newRule(rules.ReflectionCondition{Context: "script"}, 5),
+			newRule(rules.ReflectionCondition{Context: "comment"}, 2),
+		}
+
+		assert(t, NewEvaluationResult(5, false), EvaluateReflections(reflections, ruleList))
+	})
+
+	t.Run("conditions must hold on a single reflection", func(t *testing.T) {
+		rule := newRule(rules.ReflectionCondition{Context: "text", Unescaped: &unescaped}, 3)
+		assert(t, DefaultEvaluationResult(), EvaluateReflections(reflections, []rules.Rule{rule}))
+	})
+
+	t.Run("target not probed", func(t *testing.T) {
+		assert(t, DefaultEvaluationResult(), EvaluateReflections(nil, []rules.Rule{newRule(rules.ReflectionCondition{}, 1)}))
+	})
+}
@@ -0,0 +1,62 @@
+package evaluator
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Scope limits which discovered URLs are evaluated, based on their host
+type Scope struct {
+	patterns []string
+}
+
+// Patterns are host names, optionally starting with a wildcard label
+// (`*.example.com` matches any subdomain of example.com, but not example.com itself)
+func NewScope(patterns []string) *Scope {
+	scope := &Scope{}
+
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+
+		if pattern != "" {
+			scope.patterns = append(scope.patterns, pattern)
+		}
+	}
+
+	return scope
+}
+
+// Creates a scope with the hosts of the given URLs
+func NewScopeFromUrls(targetUrls []string) *Scope {
+	var hosts []string
+
+	for _, targetUrl := range targetUrls {
+		if parsed, err := url.Parse(targetUrl); err == nil && parsed.Hostname() != "" {
+			hosts = append(hosts, parsed.Hostname())
+		}
+	}
+
+	return NewScope(hosts)
+}
+
+func (scope *Scope) Contains(targetUrl string) bool {
+	parsed, err := url.Parse(targetUrl)
+
+	if err != nil {
+		return false
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+
+	for _, pattern := range scope.patterns {
+		if wildcard, found := strings.CutPrefix(pattern, "*."); found {
+			if strings.HasSuffix(host, "."+wildcard) {
+				return true
+			}
+		} else if host == pattern {
+			return true
+		}
+	}
+
+	return false
+}
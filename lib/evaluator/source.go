@@ -0,0 +1,95 @@
+package evaluator
+
+import (
+	"bytes"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// html.Parse doesn't keep track of positions, so line numbers are recovered by tokenizing the raw body again
+// and pairing the nth start tag of each element name with the nth parsed element of the same name. Elements
+// implied by the parser (html, head, body, tbody) have no matching token, and might have a wrong or unknown line
+type sourceIndex struct {
+	body  []byte
+	lines map[*html.Node]int
+}
+
+func newSourceIndex(document *html.Node, body []byte) *sourceIndex {
+	index := &sourceIndex{
+		body:  body,
+		lines: make(map[*html.Node]int),
+	}
+
+	if document == nil || len(body) == 0 {
+		return index
+	}
+
+	tagLines := make(map[string][]int)
+	tokenizer := html.NewTokenizer(bytes.NewReader(body))
+	line := 1
+
+	for {
+		tokenType := tokenizer.Next()
+
+		if tokenType == html.ErrorToken {
+			break
+		}
+
+		raw := tokenizer.Raw()
+
+		if tokenType == html.StartTagToken || tokenType == html.SelfClosingTagToken {
+			name, _ := tokenizer.TagName()
+			tagLines[string(name)] = append(tagLines[string(name)], line)
+		}
+
+		line += bytes.Count(raw, []byte("\n"))
+	}
+
+	seen := make(map[string]int)
+
+	for node := range document.Descendants() {
+		if node.Type != html.ElementNode {
+			continue
+		}
+
+		position := seen[node.Data]
+		seen[node.Data]++
+
+		if lines := tagLines[node.Data]; position < len(lines) {
+			index.lines[node] = lines[position]
+		}
+	}
+
+	return index
+}
+
+// Returns the line where a node starts on the raw body, or 0 when it can't be found
+func (index *sourceIndex) Line(node *html.Node) int {
+	if node == nil {
+		return 0
+	}
+
+	if node.Type == html.ElementNode {
+		return index.lines[node]
+	}
+
+	// Text nodes are located by their first non-empty line
+	for _, text := range strings.Split(node.Data, "\n") {
+		text = strings.TrimSpace(text)
+
+		if text == "" {
+			continue
+		}
+
+		position := bytes.Index(index.body, []byte(text))
+
+		if position == -1 {
+			return 0
+		}
+
+		return 1 + bytes.Count(index.body[:position], []byte("\n"))
+	}
+
+	return 0
+}
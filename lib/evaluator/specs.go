@@ -0,0 +1,44 @@
+package evaluator
+
+import (
+	"bloodhound/lib/apispec"
+	"bloodhound/lib/evaluator/pipeline"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Looks for an API spec once per host of the targets, and adds the spec and its in-scope endpoints as targets.
+// Specs are recorded on the target of the spec document, endpoints on the target created for them
+func DiscoverSpecs(finder *apispec.Finder, contexts []pipeline.Context, scope *Scope) []pipeline.Context {
+	targets := newTargetList(contexts)
+
+	for _, origin := range origins(contexts) {
+		spec := finder.Find(origin)
+
+		if spec == nil {
+			continue
+		}
+
+		log.WithFields(log.Fields{
+			"spec":      spec.Url,
+			"version":   spec.Version,
+			"endpoints": len(spec.Endpoints),
+		}).Info("Found API spec")
+
+		context, _ := targets.add(spec.Url)
+		context.Spec = spec
+		context.AddSource(pipeline.NewSource(pipeline.WellKnownSource, origin, ""))
+
+		for _, endpoint := range spec.Endpoints {
+			if !scope.Contains(endpoint.Url) {
+				continue
+			}
+
+			context, _ := targets.add(endpoint.Url)
+			context.Endpoint = &endpoint
+			context.AddSource(pipeline.NewSource(pipeline.SpecSource, spec.Url, endpoint.Path))
+		}
+	}
+
+	return targets.contexts
+}
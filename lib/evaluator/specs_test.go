@@ -0,0 +1,58 @@
+package evaluator
+
+import (
+	"bloodhound/lib/apispec"
+	"bloodhound/lib/client"
+	"bloodhound/lib/evaluator/pipeline"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDiscoverSpecs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/openapi.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		fmt.Fprint(w, `{"openapi": "3.0.0", "servers": [{"url": "https://other.example.com"}], "paths": {"/admin": {"get": {}}}}`)
+	}))
+	defer server.Close()
+
+	limiter := client.NewRateLimiter(1000)
+	defer limiter.Stop()
+
+	finder := apispec.NewFinder(client.NewClient(client.ClientConfig{}), limiter)
+
+	input := []pipeline.Context{
+		pipeline.NewContext(server.URL + "/"),
+		pipeline.NewContext(server.URL + "/openapi.json"),
+	}
+
+	t.Run("spec recorded on existing target", func(t *testing.T) {
+		contexts := DiscoverSpecs(finder, input, NewScopeFromUrls([]string{server.URL}))
+
+		if len(contexts) != 2 {
+			t.Fatalf("DiscoverSpecs; want 2 targets; got %d", len(contexts))
+		}
+
+		if contexts[1].Spec == nil || contexts[0].Spec != nil || input[1].Spec != nil {
+			t.Errorf("DiscoverSpecs; want spec on %s; got %+v", input[1].Url, contexts)
+		}
+	})
+
+	t.Run("in-scope endpoints added", func(t *testing.T) {
+		contexts := DiscoverSpecs(finder, input[:1], NewScope([]string{"127.0.0.1", "other.example.com"}))
+
+		if len(contexts) != 3 {
+			t.Fatalf("DiscoverSpecs; want 3 targets; got %d", len(contexts))
+		}
+
+		endpoint := contexts[2]
+		if endpoint.Url != "https://other.example.com/admin" || endpoint.Endpoint == nil {
+			t.Errorf("DiscoverSpecs; want endpoint target https://other.example.com/admin; got %+v", endpoint)
+		}
+	})
+}
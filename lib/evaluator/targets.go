@@ -0,0 +1,62 @@
+package evaluator
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"net/url"
+	"slices"
+)
+
+// Targets in order of appearance, targets found more than once are only added the first time
+type targetList struct {
+	contexts []pipeline.Context
+	indexes  map[string]int
+}
+
+func newTargetList(contexts []pipeline.Context) *targetList {
+	list := &targetList{
+		contexts: slices.Clone(contexts),
+		indexes:  make(map[string]int),
+	}
+
+	for i, context := range list.contexts {
+		list.indexes[context.Url] = i
+	}
+
+	return list
+}
+
+// Returns the context of the URL, so facts can be recorded on targets that were already on the list.
+// The context is only valid until the next call
+func (list *targetList) add(targetUrl string) (*pipeline.Context, bool) {
+	if i, found := list.indexes[targetUrl]; found {
+		return &list.contexts[i], false
+	}
+
+	list.indexes[targetUrl] = len(list.contexts)
+	list.contexts = append(list.contexts, pipeline.NewContext(targetUrl))
+
+	return &list.contexts[len(list.contexts)-1], true
+}
+
+// Scheme and host of every target, in order of appearance
+func origins(contexts []pipeline.Context) []string {
+	var result []string
+	seen := make(map[string]bool)
+
+	for _, context := range contexts {
+		parsed, err := url.Parse(context.Url)
+
+		if err != nil || parsed.Host == "" {
+			continue
+		}
+
+		origin := parsed.Scheme + "://" + parsed.Host
+
+		if !seen[origin] {
+			seen[origin] = true
+			result = append(result, origin)
+		}
+	}
+
+	return result
+}
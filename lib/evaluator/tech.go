@@ -0,0 +1,43 @@
+package evaluator
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/rules"
+	"bloodhound/lib/tech"
+	"strings"
+)
+
+func EvaluateTechnologies(technologies []tech.Technology, ruleList []rules.Rule) EvaluationResult {
+	result := DefaultEvaluationResult()
+
+	for _, rule := range ruleList {
+		if rule.Level != rules.TechLevel {
+			continue
+		}
+
+		technology, found := findTechnology(technologies, rule.Content.Technology)
+
+		if !found || !tech.MatchesConstraint(technology.Version, rule.Content.Version) {
+			continue
+		}
+
+		if rule.Remove {
+			return NewEvaluationResult(0, rule.Remove)
+		}
+
+		result.addMatch(pipeline.NewMatch(rule, "technology ("+technology.Evidence+")", technology.String()))
+	}
+
+	return result
+}
+
+// Technology names on rules are case insensitive
+func findTechnology(technologies []tech.Technology, name string) (tech.Technology, bool) {
+	for _, technology := range technologies {
+		if strings.EqualFold(technology.Name, name) {
+			return technology, true
+		}
+	}
+
+	return tech.Technology{}, false
+}
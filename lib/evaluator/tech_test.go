@@ -0,0 +1,55 @@
+package evaluator
+
+import (
+	"bloodhound/lib/rules"
+	"bloodhound/lib/tech"
+	"testing"
+)
+
+func TestEvaluateTechnologies(t *testing.T) {
+	technologies := []tech.Technology{
+		{Name: "WordPress", Version: "5.8.2"},
+		{Name: "jQuery"},
+	}
+
+	assert := func(t testing.TB, expected EvaluationResult, actual EvaluationResult) {
+		t.Helper()
+		if expected.Score != actual.Score || expected.Remove != actual.Remove {
+			t.Errorf("EvaluateTechnologies; want %v; got %v", expected, actual)
+		}
+	}
+
+	newRule := func(technology string, version string, value float64, remove bool) rules.Rule {
+		rule := rules.NewRule("Runs "+technology, rules.TechLevel, value, remove, rules.RuleContent{
+			Technology: technology,
+			Version:    version,
+		})
+
+		return rule
+	}
+
+	t.Run("detected technology within version constraint", func(t *testing.T) {
+		result := EvaluateTechnologies(technologies, []rules.Rule{newRule("wordpress", "< 6", 3, false)})
+		assert(t, NewEvaluationResult(3, false), result)
+	})
+
+	t.Run("detected technology outside version constraint", func(t *testing.T) {
+		result := EvaluateTechnologies(technologies, []rules.Rule{newRule("WordPress", ">= 6", 3, false)})
+		assert(t, DefaultEvaluationResult(), result)
+	})
+
+	t.Run("version constraint on technology without visible version", func(t *testing.T) {
+		result := EvaluateTechnologies(technologies, []rules.Rule{newRule("jQuery", "1.x", 2, false)})
+		assert(t, DefaultEvaluationResult(), result)
+	})
+
+	t.Run("technology not detected", func(t *testing.T) {
+		result := EvaluateTechnologies(technologies, []rules.Rule{newRule("Drupal", "", 3, false)})
+		assert(t, DefaultEvaluationResult(), result)
+	})
+
+	t.Run("remove rule", func(t *testing.T) {
+		result := EvaluateTechnologies(technologies, []rules.Rule{newRule("jQuery", "", 0, true), newRule("WordPress", "", 3, false)})
+		assert(t, NewEvaluationResult(0, true), result)
+	})
+}
@@ -1,12 +1,15 @@
 package evaluator
 
 import (
+	"bloodhound/lib/evaluator/pipeline"
 	"bloodhound/lib/rules"
 	"bloodhound/lib/utils"
+	"net/url"
+	"strings"
 )
 
 func EvaluateUrl(url *string, ruleList *[]rules.Rule) EvaluationResult {
-	score := 0
+	result := DefaultEvaluationResult()
 
 	for _, rule := range *ruleList {
 		if rule.Level != rules.ResourceLevel {
@@ -17,14 +20,36 @@ func EvaluateUrl(url *string, ruleList *[]rules.Rule) EvaluationResult {
 			continue
 		}
 
-		if utils.ContainsAny(*url, rule.Content.Matches) {
+		if word, found := utils.FindAny(*url, rule.Content.Matches); found {
 			if rule.Remove {
 				return NewEvaluationResult(0, rule.Remove)
 			}
 
-			score += rule.Value
+			result.addMatch(pipeline.NewMatch(rule, urlLocation(*url, word), word))
 		}
 	}
 
-	return NewEvaluationResult(score, false)
+	return result
+}
+
+// Names the part of the URL that contains the matched word
+func urlLocation(targetUrl string, word string) string {
+	parsed, err := url.Parse(targetUrl)
+
+	if err != nil {
+		return "URL"
+	}
+
+	switch {
+	case strings.Contains(parsed.Host, word):
+		return "URL host"
+	case strings.Contains(parsed.Path, word):
+		return "URL path"
+	case strings.Contains(parsed.RawQuery, word):
+		return "URL query"
+	case strings.Contains(parsed.Fragment, word):
+		return "URL fragment"
+	default:
+		return "URL"
+	}
 }
@@ -16,7 +16,7 @@ func TestEvaluateUrl(t *testing.T) {
 	}
 
 	assert := func(t *testing.T, expected EvaluationResult, actual EvaluationResult) {
-		if expected != actual {
+		if expected.Score != actual.Score || expected.Remove != actual.Remove {
 			t.Errorf("EvaluateUrl; want %+v; got %+v", expected, actual)
 		}
 	}
@@ -0,0 +1,241 @@
+package graphql
+
+import (
+	"bloodhound/lib/client"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Harmless query every GraphQL server answers with the name of its query type
+const typenameQuery = "query{__typename}"
+
+// Introspection query reduced to what the summary needs
+const introspectionQuery = "query{__schema{queryType{name} mutationType{name} subscriptionType{name} types{name kind fields{name}}}}"
+
+// Field names that usually hold credentials, personal data or privileges
+var sensitiveWords = []string{"password", "passwd", "token", "secret", "apikey", "api_key", "admin", "role", "permission", "ssn", "creditcard", "card_number", "private"}
+
+// Words in URLs of likely GraphQL endpoints, other targets are never probed
+var endpointWords = []string{"graphql", "graphiql", "/gql", "/graph"}
+
+type Summary struct {
+	// Endpoint answered the __typename query
+	Confirmed bool `json:"confirmed"`
+
+	Introspection bool `json:"introspection"`
+	Queries       int  `json:"queries"`
+	Mutations     int  `json:"mutations"`
+	Subscriptions int  `json:"subscriptions"`
+	Types         int  `json:"types"`
+
+	// Every field of the schema (Type.field), and the ones named like sensitive data, only available with introspection
+	Fields          []string `json:"fields,omitempty"`
+	SensitiveFields []string `json:"sensitiveFields,omitempty"`
+}
+
+func IsCandidate(targetUrl string) bool {
+	lower := strings.ToLower(targetUrl)
+
+	for _, word := range endpointWords {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type response struct {
+	Data json.RawMessage `json:"data"`
+}
+
+type schemaResponse struct {
+	Schema struct {
+		QueryType        *namedType `json:"queryType"`
+		MutationType     *namedType `json:"mutationType"`
+		SubscriptionType *namedType `json:"subscriptionType"`
+		Types            []struct {
+			Name   string      `json:"name"`
+			Kind   string      `json:"kind"`
+			Fields []namedType `json:"fields"`
+		} `json:"types"`
+	} `json:"__schema"`
+}
+
+type namedType struct {
+	Name string `json:"name"`
+}
+
+type Analyzer struct {
+	client  *client.BloodhoundClient
+	limiter *client.RateLimiter
+}
+
+func NewAnalyzer(client *client.BloodhoundClient, limiter *client.RateLimiter) *Analyzer {
+	return &Analyzer{
+		client:  client,
+		limiter: limiter,
+	}
+}
+
+// Confirms the endpoint with a __typename query, and summarizes its schema when introspection is enabled.
+// Returns nil when the target doesn't answer like a GraphQL endpoint
+func (analyzer *Analyzer) Analyze(endpoint string) *Summary {
+	data, err := analyzer.query(endpoint, typenameQuery)
+
+	if err != nil {
+		return nil
+	}
+
+	var typename struct {
+		Typename string `json:"__typename"`
+	}
+
+	if json.Unmarshal(data, &typename) != nil || typename.Typename == "" {
+		return nil
+	}
+
+	summary := &Summary{Confirmed: true}
+
+	data, err = analyzer.query(endpoint, introspectionQuery)
+
+	if err != nil {
+		return summary
+	}
+
+	var schema schemaResponse
+
+	if json.Unmarshal(data, &schema) != nil || schema.Schema.QueryType == nil {
+		return summary
+	}
+
+	summarizeSchema(summary, &schema)
+
+	return summary
+}
+
+func summarizeSchema(summary *Summary, schema *schemaResponse) {
+	summary.Introspection = true
+
+	rootName := func(root *namedType) string {
+		if root == nil {
+			return ""
+		}
+
+		return root.Name
+	}
+
+	queryType := rootName(schema.Schema.QueryType)
+	mutationType := rootName(schema.Schema.MutationType)
+	subscriptionType := rootName(schema.Schema.SubscriptionType)
+
+	for _, schemaType := range schema.Schema.Types {
+		// Built-in introspection types describe every schema the same way
+		if strings.HasPrefix(schemaType.Name, "__") {
+			continue
+		}
+
+		summary.Types++
+
+		switch schemaType.Name {
+		case queryType:
+			summary.Queries = len(schemaType.Fields)
+		case mutationType:
+			summary.Mutations = len(schemaType.Fields)
+		case subscriptionType:
+			summary.Subscriptions = len(schemaType.Fields)
+		}
+
+		for _, field := range schemaType.Fields {
+			name := schemaType.Name + "." + field.Name
+			summary.Fields = append(summary.Fields, name)
+
+			if isSensitive(field.Name) {
+				summary.SensitiveFields = append(summary.SensitiveFields, name)
+			}
+		}
+	}
+
+	slices.Sort(summary.Fields)
+	slices.Sort(summary.SensitiveFields)
+}
+
+func isSensitive(name string) bool {
+	lower := strings.ToLower(name)
+
+	for _, word := range sensitiveWords {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Sends a query as a JSON POST request, falling back to GET since some servers only accept one of them
+func (analyzer *Analyzer) query(endpoint string, query string) (json.RawMessage, error) {
+	body, _ := json.Marshal(map[string]string{"query": query})
+
+	data, err := analyzer.send(endpoint, func() (*http.Request, error) {
+		request, err := http.NewRequest("POST", endpoint, bytes.NewReader(body))
+
+		if err == nil {
+			request.Header.Set("Content-Type", "application/json")
+		}
+
+		return request, err
+	})
+
+	if err == nil {
+		return data, nil
+	}
+
+	return analyzer.send(endpoint, func() (*http.Request, error) {
+		request, err := http.NewRequest("GET", endpoint, nil)
+
+		if err == nil {
+			values := request.URL.Query()
+			values.Set("query", query)
+			request.URL.RawQuery = values.Encode()
+		}
+
+		return request, err
+	})
+}
+
+func (analyzer *Analyzer) send(endpoint string, newRequest func() (*http.Request, error)) (json.RawMessage, error) {
+	analyzer.limiter.Wait()
+
+	request, err := newRequest()
+
+	if err != nil {
+		return nil, err
+	}
+
+	httpResponse, err := analyzer.client.Do(request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer httpResponse.Body.Close()
+
+	content, err := io.ReadAll(httpResponse.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed response
+
+	if err := json.Unmarshal(content, &parsed); err != nil || len(parsed.Data) == 0 || string(parsed.Data) == "null" {
+		return nil, fmt.Errorf("unable to find GraphQL data on response of %s", endpoint)
+	}
+
+	return parsed.Data, nil
+}
@@ -0,0 +1,124 @@
+package graphql
+
+import (
+	"bloodhound/lib/client"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const schema = `{"data":{"__schema":{
+	"queryType":{"name":"Query"},"mutationType":{"name":"Mutation"},"subscriptionType":null,
+	"types":[
+		{"name":"Query","kind":"OBJECT","fields":[{"name":"user"},{"name":"users"}]},
+		{"name":"Mutation","kind":"OBJECT","fields":[{"name":"login"},{"name":"resetPassword"},{"name":"deleteUser"}]},
+		{"name":"User","kind":"OBJECT","fields":[{"name":"id"},{"name":"email"},{"name":"passwordHash"},{"name":"apiKey"}]},
+		{"name":"String","kind":"SCALAR","fields":null},
+		{"name":"__Schema","kind":"OBJECT","fields":[{"name":"types"}]}
+	]}}}`
+
+func TestIsCandidate(t *testing.T) {
+	for targetUrl, expected := range map[string]bool{
+		"https://example.com/graphql":        true,
+		"https://example.com/api/GraphQL/v1": true,
+		"https://example.com/gql":            true,
+		"https://example.com/graphiql?x=1":   true,
+		"https://example.com/photography":    false,
+		"https://example.com/api/users":      false,
+	} {
+		if actual := IsCandidate(targetUrl); actual != expected {
+			t.Errorf("IsCandidate(%q); want %v; got %v", targetUrl, expected, actual)
+		}
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	limiter := client.NewRateLimiter(1000)
+	defer limiter.Stop()
+
+	analyzer := NewAnalyzer(client.NewClient(client.ClientConfig{}), limiter)
+
+	newServer := func(introspection bool, getOnly bool) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query().Get("query")
+
+			if r.Method == "POST" {
+				if getOnly {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					return
+				}
+
+				var body struct {
+					Query string `json:"query"`
+				}
+				json.NewDecoder(r.Body).Decode(&body)
+				query = body.Query
+			}
+
+			switch {
+			case query == "":
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"errors":[{"message":"Must provide query string."}]}`)
+			case strings.Contains(query, "__schema") && introspection:
+				fmt.Fprint(w, schema)
+			case strings.Contains(query, "__schema"):
+				fmt.Fprint(w, `{"errors":[{"message":"GraphQL introspection is not allowed"}]}`)
+			default:
+				fmt.Fprint(w, `{"data":{"__typename":"Query"}}`)
+			}
+		}))
+	}
+
+	t.Run("introspection enabled", func(t *testing.T) {
+		server := newServer(true, false)
+		defer server.Close()
+
+		summary := analyzer.Analyze(server.URL + "/graphql")
+
+		if summary == nil {
+			t.Fatal("Analyze; want summary; got nil")
+		}
+
+		expected := Summary{Confirmed: true, Introspection: true, Queries: 2, Mutations: 3, Types: 4}
+		if expected.Confirmed != summary.Confirmed || expected.Introspection != summary.Introspection ||
+			expected.Queries != summary.Queries || expected.Mutations != summary.Mutations || expected.Types != summary.Types {
+			t.Errorf("Analyze; want %+v; got %+v", expected, *summary)
+		}
+
+		fields := []string{"Mutation.deleteUser", "Mutation.login", "Mutation.resetPassword", "Query.user", "Query.users", "User.apiKey", "User.email", "User.id", "User.passwordHash"}
+		if !slices.Equal(fields, summary.Fields) {
+			t.Errorf("Analyze; want fields %v; got %v", fields, summary.Fields)
+		}
+
+		sensitive := []string{"Mutation.resetPassword", "User.apiKey", "User.passwordHash"}
+		if !slices.Equal(sensitive, summary.SensitiveFields) {
+			t.Errorf("Analyze; want sensitive fields %v; got %v", sensitive, summary.SensitiveFields)
+		}
+	})
+
+	t.Run("introspection disabled", func(t *testing.T) {
+		server := newServer(false, true)
+		defer server.Close()
+
+		summary := analyzer.Analyze(server.URL + "/graphql")
+
+		if summary == nil || !summary.Confirmed || summary.Introspection {
+			t.Errorf("Analyze; want confirmed endpoint without introspection; got %+v", summary)
+		}
+	})
+
+	t.Run("not a GraphQL endpoint", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"data":null,"message":"graph of the week"}`)
+		}))
+		defer server.Close()
+
+		if summary := analyzer.Analyze(server.URL + "/graph"); summary != nil {
+			t.Errorf("Analyze; want nil; got %+v", *summary)
+		}
+	})
+}
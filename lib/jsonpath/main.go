@@ -0,0 +1,243 @@
+package jsonpath
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Keys that can be written with dot notation on paths of found nodes
+var identifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$-]*$`)
+
+// Subset of JSONPath: $, .key, ['key'], [0], [*], .* and recursive descent (..key, ..*, ..[0])
+type Path struct {
+	raw      string
+	segments []segment
+}
+
+type segment struct {
+	// Selector applies to the node and every descendant of it
+	recursive bool
+
+	wildcard bool
+	key      string
+	index    int
+	isIndex  bool
+}
+
+// Node found on a document, with its path from the root (e.g. $.users[0].email)
+type Node struct {
+	Path string
+
+	// Key of the node on its parent object, empty for array items and the root
+	Key   string
+	Value any
+}
+
+func Compile(raw string) (*Path, error) {
+	rest, found := strings.CutPrefix(strings.TrimSpace(raw), "$")
+
+	if !found {
+		return nil, fmt.Errorf("invalid JSON path %q, paths start with $", raw)
+	}
+
+	path := &Path{raw: raw}
+
+	for rest != "" {
+		var current segment
+
+		if after, found := strings.CutPrefix(rest, ".."); found {
+			current.recursive = true
+			rest = after
+
+			// Bracket selectors may follow recursive descent directly ($..[0])
+			if !strings.HasPrefix(rest, "[") {
+				rest = "." + rest
+			}
+		}
+
+		switch {
+		case strings.HasPrefix(rest, "."):
+			end := strings.IndexAny(rest[1:], ".[")
+			if end == -1 {
+				end = len(rest) - 1
+			}
+
+			name := rest[1 : end+1]
+			rest = rest[end+1:]
+
+			if name == "" {
+				return nil, fmt.Errorf("invalid JSON path %q, empty key", raw)
+			}
+
+			if name == "*" {
+				current.wildcard = true
+			} else {
+				current.key = name
+			}
+
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end == -1 {
+				return nil, fmt.Errorf("invalid JSON path %q, unclosed bracket", raw)
+			}
+
+			selector := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+
+			if err := current.parseBracket(selector); err != nil {
+				return nil, fmt.Errorf("invalid JSON path %q, %s", raw, err.Error())
+			}
+
+		default:
+			return nil, fmt.Errorf("invalid JSON path %q, unexpected %q", raw, rest)
+		}
+
+		path.segments = append(path.segments, current)
+	}
+
+	return path, nil
+}
+
+func (current *segment) parseBracket(selector string) error {
+	if selector == "*" {
+		current.wildcard = true
+		return nil
+	}
+
+	if len(selector) >= 2 && (selector[0] == '\'' || selector[0] == '"') && selector[len(selector)-1] == selector[0] {
+		current.key = selector[1 : len(selector)-1]
+		return nil
+	}
+
+	index, err := strconv.Atoi(selector)
+
+	if err != nil || index < 0 {
+		return fmt.Errorf("unsupported selector [%s]", selector)
+	}
+
+	current.index = index
+	current.isIndex = true
+
+	return nil
+}
+
+func (path *Path) String() string {
+	return path.raw
+}
+
+// Every node of the document (decoded with encoding/json) selected by the path, in document order
+func (path *Path) Find(document any) []Node {
+	nodes := []Node{{Path: "$", Value: document}}
+
+	for _, current := range path.segments {
+		if current.recursive {
+			var expanded []Node
+			for _, node := range nodes {
+				expanded = append(expanded, Descendants(node)...)
+			}
+
+			nodes = expanded
+		}
+
+		var selected []Node
+		for _, node := range nodes {
+			selected = append(selected, current.selectFrom(node)...)
+		}
+
+		nodes = selected
+	}
+
+	return nodes
+}
+
+func (current *segment) selectFrom(node Node) []Node {
+	switch value := node.Value.(type) {
+	case map[string]any:
+		if current.isIndex {
+			return nil
+		}
+
+		if current.wildcard {
+			return children(node)
+		}
+
+		if child, found := value[current.key]; found {
+			return []Node{{Path: childPath(node.Path, current.key), Key: current.key, Value: child}}
+		}
+
+	case []any:
+		if current.wildcard {
+			return children(node)
+		}
+
+		if current.isIndex && current.index < len(value) {
+			return []Node{{Path: fmt.Sprintf("%s[%d]", node.Path, current.index), Value: value[current.index]}}
+		}
+	}
+
+	return nil
+}
+
+// The node itself and every node below it, in document order
+func Descendants(node Node) []Node {
+	nodes := []Node{node}
+
+	for _, child := range children(node) {
+		nodes = append(nodes, Descendants(child)...)
+	}
+
+	return nodes
+}
+
+// Object members are sorted by key, since decoded objects don't keep their order
+func children(node Node) []Node {
+	var nodes []Node
+
+	switch value := node.Value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			nodes = append(nodes, Node{Path: childPath(node.Path, key), Key: key, Value: value[key]})
+		}
+
+	case []any:
+		for i, item := range value {
+			nodes = append(nodes, Node{Path: fmt.Sprintf("%s[%d]", node.Path, i), Value: item})
+		}
+	}
+
+	return nodes
+}
+
+func childPath(parent string, key string) string {
+	if identifier.MatchString(key) {
+		return parent + "." + key
+	}
+
+	return parent + "[" + strconv.Quote(key) + "]"
+}
+
+// Scalars are formatted like their JSON form (strings without quotes), objects and arrays are empty
+func Format(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return value
+	case bool:
+		return strconv.FormatBool(value)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	return ""
+}
@@ -0,0 +1,72 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+const document = `{
+	"users": [
+		{"id": 1, "email": "alice@example.com", "isAdmin": false, "profile": {"ssn": "123-45-6789"}},
+		{"id": 2, "email": "bob@example.com", "isAdmin": true}
+	],
+	"meta": {"total": 2, "next page": null}
+}`
+
+func TestFind(t *testing.T) {
+	var data any
+	if err := json.Unmarshal([]byte(document), &data); err != nil {
+		t.Fatal(err)
+	}
+
+	assert := func(t testing.TB, raw string, expected []string) {
+		t.Helper()
+
+		path, err := Compile(raw)
+		if err != nil {
+			t.Fatalf("Compile(%q); want no error; got %s", raw, err.Error())
+		}
+
+		var actual []string
+		for _, node := range path.Find(data) {
+			actual = append(actual, node.Path+"="+Format(node.Value))
+		}
+
+		if !slices.Equal(expected, actual) {
+			t.Errorf("Find(%q); want %v; got %v", raw, expected, actual)
+		}
+	}
+
+	t.Run("child and index", func(t *testing.T) {
+		assert(t, "$.users[1].email", []string{"$.users[1].email=bob@example.com"})
+		assert(t, "$['meta']['next page']", []string{`$.meta["next page"]=null`})
+		assert(t, "$.users[5]", nil)
+	})
+
+	t.Run("wildcards", func(t *testing.T) {
+		assert(t, "$.users[*].id", []string{"$.users[0].id=1", "$.users[1].id=2"})
+		assert(t, "$.meta.*", []string{`$.meta["next page"]=null`, "$.meta.total=2"})
+	})
+
+	t.Run("recursive descent", func(t *testing.T) {
+		assert(t, "$..ssn", []string{"$.users[0].profile.ssn=123-45-6789"})
+		assert(t, "$..isAdmin", []string{"$.users[0].isAdmin=false", "$.users[1].isAdmin=true"})
+		assert(t, "$..[1].id", []string{"$.users[1].id=2"})
+	})
+
+	t.Run("root", func(t *testing.T) {
+		path, _ := Compile("$")
+		if nodes := path.Find(data); len(nodes) != 1 || nodes[0].Path != "$" {
+			t.Errorf("Find(\"$\"); want root node; got %v", nodes)
+		}
+	})
+}
+
+func TestCompile(t *testing.T) {
+	for _, raw := range []string{"users.id", "$.", "$[abc]", "$.users[0", "$[-1]"} {
+		if _, err := Compile(raw); err == nil {
+			t.Errorf("Compile(%q); want error; got nil", raw)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package output
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+//go:embed report.html
+var reportTemplate string
+
+var report = template.Must(template.New("report").Funcs(template.FuncMap{
+	"join": strings.Join,
+	"statusClass": func(status int) string {
+		if status == 0 {
+			return ""
+		}
+
+		return fmt.Sprintf("status-%d", status/100)
+	},
+}).Parse(reportTemplate))
+
+type reportData struct {
+	Generated  string
+	Targets    []reportTarget
+	Categories []string
+	Tags       []string
+}
+
+type reportTarget struct {
+	Result
+
+	Rank        int
+	Title       string
+	ContentType string
+	Matches     []reportMatch
+
+	// Categories and tags of matched rules, used to filter the table
+	MatchCategories []string
+	MatchTags       []string
+}
+
+type reportMatch struct {
+	RuleResult
+
+	Category string
+	Location string
+	Snippet  string
+}
+
+// HTML output is a single file report, with styles and scripts inlined so it can be shared as is
+func writeHtml(writer io.Writer, results []pipeline.Context) error {
+	data := reportData{
+		Generated: time.Now().Format(time.RFC1123),
+	}
+
+	for rank, context := range results {
+		target := reportTarget{
+			Result: NewResult(context),
+			Rank:   rank + 1,
+			Title:  pageTitle(context.Content),
+		}
+
+		if context.Response != nil {
+			target.ContentType = context.Response.Header.Get("Content-Type")
+		}
+
+		for _, match := range context.Matches {
+			category := match.Rule.Category
+			if category == "" {
+				category = UncategorizedCategory
+			}
+
+			target.Matches = append(target.Matches, reportMatch{
+				RuleResult: RuleResult{
+					Rule:        match.Rule.Name,
+					Description: match.Rule.Description,
+					Value:       match.Rule.Value,
+					Multiplier:  match.Rule.Multiplier,
+					Severity:    string(match.Rule.Severity),
+					Tags:        match.Rule.Tags,
+				},
+				Category: category,
+				Location: match.Location,
+				Snippet:  match.Snippet,
+			})
+
+			target.MatchCategories = appendUnique(target.MatchCategories, category)
+			target.MatchTags = appendUnique(target.MatchTags, match.Rule.Tags...)
+		}
+
+		data.Categories = appendUnique(data.Categories, target.MatchCategories...)
+		data.Tags = appendUnique(data.Tags, target.MatchTags...)
+		data.Targets = append(data.Targets, target)
+	}
+
+	slices.Sort(data.Categories)
+	slices.Sort(data.Tags)
+
+	return report.Execute(writer, data)
+}
+
+func appendUnique(values []string, items ...string) []string {
+	for _, item := range items {
+		if !slices.Contains(values, item) {
+			values = append(values, item)
+		}
+	}
+
+	return values
+}
+
+// Text of the first title element of the page, empty for responses without HTML content
+func pageTitle(node *html.Node) string {
+	if node == nil {
+		return ""
+	}
+
+	if node.Type == html.ElementNode && node.DataAtom == atom.Title {
+		var text strings.Builder
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type == html.TextNode {
+				text.WriteString(child.Data)
+			}
+		}
+
+		return strings.Join(strings.Fields(text.String()), " ")
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if title := pageTitle(child); title != "" {
+			return title
+		}
+	}
+
+	return ""
+}
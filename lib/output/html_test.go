@@ -0,0 +1,53 @@
+package output
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/rules"
+	"bloodhound/lib/secrets"
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestWriteHtml(t *testing.T) {
+	context := pipeline.NewContext("http://localhost/login?next=<script>")
+	context.Score = 4
+	context.SetResponse(&pipeline.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"text/html"}},
+		Body:       []byte("<html><head><title>\n  Sign in  </title></head><body><form><input type=password></form></body></html>"),
+	})
+
+	rule := rules.NewRule("Has password input?", rules.ContentLevel, 2, false, rules.RuleContent{})
+	rule.Category = "auth"
+	rule.Tags = []string{"login"}
+	context.Matches = []pipeline.Match{pipeline.NewMatch(rule, "element", `<input type="password">`)}
+	context.Findings = []secrets.Finding{{Type: "jwt", Location: "script", Preview: "eyJh****"}}
+	context.Duplicates = []string{"http://localhost/signin"}
+
+	var buffer bytes.Buffer
+
+	if err := writeHtml(&buffer, []pipeline.Context{context}); err != nil {
+		t.Fatalf("writeHtml; got error %s", err.Error())
+	}
+
+	report := buffer.String()
+
+	for _, expected := range []string{
+		`<div class="title">Sign in</div>`,
+		`class="number status-2"`,
+		`data-categories="auth" data-tags="login"`,
+		`<option>auth</option>`,
+		`<td>Has password input?</td>`,
+		`&lt;input type=&#34;password&#34;&gt;`,
+		`<span class="badge finding">jwt</span>`,
+		`login?next=&lt;script&gt;`,
+		`+1 near-duplicates`,
+		`<span class="url">http://localhost/signin</span>`,
+	} {
+		if !strings.Contains(report, expected) {
+			t.Errorf("writeHtml; want %q on report; got none", expected)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package output
+
+import (
+	"bloodhound/lib/apispec"
+	"bloodhound/lib/discovery"
+	"bloodhound/lib/evaluator/pipeline"
+	"bloodhound/lib/graphql"
+	"bloodhound/lib/secrets"
+	"bloodhound/lib/simhash"
+	"bloodhound/lib/tech"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Category used to group matches of rules without a category
+const UncategorizedCategory = "uncategorized"
+
+type Result struct {
+	Url        string                  `json:"url"`
+	Score      float64                 `json:"score"`
+	Status     int                     `json:"status,omitempty"`
+	Sources    []pipeline.Source       `json:"sources,omitempty"`
+	Categories map[string][]RuleResult `json:"categories,omitempty"`
+
+	Technologies []tech.Technology     `json:"technologies,omitempty"`
+	Findings     []secrets.Finding     `json:"findings,omitempty"`
+	Forms        []pipeline.Form       `json:"forms,omitempty"`
+	Reflections  []pipeline.Reflection `json:"reflections,omitempty"`
+	Parameters   []discovery.Parameter `json:"parameters,omitempty"`
+	Graphql      *graphql.Summary      `json:"graphql,omitempty"`
+	Spec         *apispec.Spec         `json:"spec,omitempty"`
+	Endpoint     *apispec.Endpoint     `json:"endpoint,omitempty"`
+
+	// Simhash of the response body, so runs can be compared without storing responses
+	Hash string `json:"hash,omitempty"`
+
+	// Differences with the baseline run, and the rank boost of new targets, when comparing with a baseline
+	Changes []string `json:"changes,omitempty"`
+	Boost   float64  `json:"boost,omitempty"`
+
+	// Number and URLs of near-duplicates of the target, when results are clustered
+	Duplicates int      `json:"duplicates,omitempty"`
+	Members    []string `json:"members,omitempty"`
+}
+
+type RuleResult struct {
+	Rule        string   `json:"rule"`
+	Description string   `json:"description,omitempty"`
+	Value       float64  `json:"value"`
+	Multiplier  float64  `json:"multiplier,omitempty"`
+	Severity    string   `json:"severity,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+func NewResult(context pipeline.Context) Result {
+	result := Result{
+		Url:          context.Url,
+		Score:        context.Score,
+		Sources:      context.Sources,
+		Categories:   make(map[string][]RuleResult),
+		Technologies: context.Technologies,
+		Findings:     context.Findings,
+		Forms:        context.Forms,
+		Reflections:  context.Reflections,
+		Parameters:   context.Parameters,
+		Graphql:      context.Graphql,
+		Spec:         context.Spec,
+		Endpoint:     context.Endpoint,
+		Duplicates:   len(context.Duplicates),
+		Members:      context.Duplicates,
+		Changes:      context.Changes,
+		Boost:        context.Boost,
+	}
+
+	if context.Response != nil {
+		result.Status = context.Response.StatusCode
+		result.Hash = fmt.Sprintf("%016x", simhash.Text(context.Response.Body))
+	}
+
+	for _, match := range context.Matches {
+		category := match.Rule.Category
+		if category == "" {
+			category = UncategorizedCategory
+		}
+
+		result.Categories[category] = append(result.Categories[category], RuleResult{
+			Rule:        match.Rule.Name,
+			Description: match.Rule.Description,
+			Value:       match.Rule.Value,
+			Multiplier:  match.Rule.Multiplier,
+			Severity:    string(match.Rule.Severity),
+			Tags:        match.Rule.Tags,
+		})
+	}
+
+	return result
+}
+
+// JSON output groups matched rules of each target by category
+func writeJson(writer io.Writer, results []pipeline.Context) error {
+	var data []Result
+	for _, context := range results {
+		data = append(data, NewResult(context))
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(data)
+}
+
+// Reads the results of a previous run, written with the json format
+func ReadJson(path string) ([]Result, error) {
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to read results file. Reason: %s", err.Error())
+	}
+
+	var results []Result
+
+	if err := json.Unmarshal(content, &results); err != nil {
+		return nil, fmt.Errorf("unable to parse results file, only json output (--format json) can be read. Reason: %s", err.Error())
+	}
+
+	return results, nil
+}
@@ -0,0 +1,49 @@
+package output
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"fmt"
+	"os"
+)
+
+type Format string
+
+const (
+	TextFormat Format = "text"
+	JsonFormat Format = "json"
+	HtmlFormat Format = "html"
+)
+
+func ParseFormat(format string) (Format, error) {
+	switch Format(format) {
+	case TextFormat, JsonFormat, HtmlFormat:
+		return Format(format), nil
+	default:
+		return TextFormat, fmt.Errorf("unknown output format: %s", format)
+	}
+}
+
+// TODO: Write to /temp if unable to write to configured output
+func Write(format Format, outputFile string, results []pipeline.Context) error {
+	file, err := os.Create(outputFile)
+	if err != nil {
+		return fmt.Errorf("unable to create output file")
+	}
+
+	defer file.Close()
+
+	switch format {
+	case JsonFormat:
+		err = writeJson(file, results)
+	case HtmlFormat:
+		err = writeHtml(file, results)
+	default:
+		err = writeText(file, results)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return file.Sync()
+}
@@ -0,0 +1,30 @@
+package output
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// Text output only contains the sorted list of URLs, so it can be piped into other tools. When results are
+// clustered, the number of collapsed near-duplicates follows the URL after a tab, so `cut -f1` still gives URLs
+func writeText(writer io.Writer, results []pipeline.Context) error {
+	buffer := bufio.NewWriter(writer)
+
+	for _, result := range results {
+		_, err := buffer.WriteString(result.Url)
+
+		if err != nil {
+			return err
+		}
+
+		if len(result.Duplicates) != 0 {
+			fmt.Fprintf(buffer, "\t(+%d near-duplicates)", len(result.Duplicates))
+		}
+
+		buffer.WriteString("\n")
+	}
+
+	return buffer.Flush()
+}
@@ -0,0 +1,24 @@
+package output
+
+import (
+	"bloodhound/lib/evaluator/pipeline"
+	"bytes"
+	"testing"
+)
+
+func TestWriteText(t *testing.T) {
+	clustered := pipeline.NewContext("http://localhost/products/1")
+	clustered.Duplicates = []string{"http://localhost/products/2", "http://localhost/products/3"}
+
+	var buffer bytes.Buffer
+
+	if err := writeText(&buffer, []pipeline.Context{clustered, pipeline.NewContext("http://localhost/login")}); err != nil {
+		t.Fatalf("writeText; got error %s", err.Error())
+	}
+
+	expected := "http://localhost/products/1\t(+2 near-duplicates)\nhttp://localhost/login\n"
+
+	if buffer.String() != expected {
+		t.Errorf("writeText; want %q; got %q", expected, buffer.String())
+	}
+}
@@ -0,0 +1,177 @@
+package recon
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Fields of security.txt whose values are URLs
+var securityTxtFields = []string{"contact", "policy", "acknowledgments", "hiring", "canonical", "encryption"}
+
+var htmlDocument = regexp.MustCompile(`(?i)^\s*(<!doctype html|<html)`)
+
+// Disallowed and allowed paths of every user agent, and the sitemaps listed by the file.
+// Wildcards are cut, so /admin/*.php becomes /admin/
+func ParseRobots(base *url.URL, body []byte) ([]Entry, []string) {
+	if htmlDocument.Match(body) {
+		return nil, nil
+	}
+
+	var disallowed []Entry
+	var allowed []Entry
+	var sitemaps []string
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		field, value, found := strings.Cut(line, ":")
+
+		if !found {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+
+		switch strings.ToLower(strings.TrimSpace(field)) {
+		case "disallow":
+			if target, valid := resolveRobotsPath(base, value); valid {
+				disallowed = append(disallowed, Entry{Url: target, Source: RobotsDisallowSource})
+			}
+		case "allow":
+			if target, valid := resolveRobotsPath(base, value); valid {
+				allowed = append(allowed, Entry{Url: target, Source: RobotsAllowSource})
+			}
+		case "sitemap":
+			if sitemap, err := base.Parse(value); err == nil && value != "" {
+				sitemaps = append(sitemaps, sitemap.String())
+			}
+		}
+	}
+
+	return append(disallowed, allowed...), sitemaps
+}
+
+func resolveRobotsPath(base *url.URL, path string) (string, bool) {
+	path, _, _ = strings.Cut(path, "*")
+	path = strings.TrimSuffix(path, "$")
+
+	if !strings.HasPrefix(path, "/") || path == "/" {
+		return "", false
+	}
+
+	target, err := base.Parse(path)
+
+	if err != nil {
+		return "", false
+	}
+
+	return target.String(), true
+}
+
+type sitemapDocument struct {
+	XMLName xml.Name
+	Urls    []struct {
+		Loc string `xml:"loc"`
+	} `xml:"url"`
+	Sitemaps []struct {
+		Loc string `xml:"loc"`
+	} `xml:"sitemap"`
+}
+
+// Pages of a sitemap, or the sitemaps listed by a sitemap index
+func ParseSitemap(body []byte) ([]string, []string, error) {
+	var document sitemapDocument
+
+	if err := xml.Unmarshal(body, &document); err != nil {
+		return nil, nil, err
+	}
+
+	if document.XMLName.Local != "urlset" && document.XMLName.Local != "sitemapindex" {
+		return nil, nil, errors.New("document is not a sitemap")
+	}
+
+	var pages []string
+	for _, page := range document.Urls {
+		if loc := strings.TrimSpace(page.Loc); isHttpUrl(loc) {
+			pages = append(pages, loc)
+		}
+	}
+
+	var sitemaps []string
+	for _, sitemap := range document.Sitemaps {
+		if loc := strings.TrimSpace(sitemap.Loc); isHttpUrl(loc) {
+			sitemaps = append(sitemaps, loc)
+		}
+	}
+
+	return pages, sitemaps, nil
+}
+
+// Policy, hiring and other pages referenced by security.txt, mail addresses are ignored
+func ParseSecurityTxt(body []byte) []Entry {
+	if htmlDocument.Match(body) {
+		return nil
+	}
+
+	var entries []Entry
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+
+	for scanner.Scan() {
+		field, value, found := strings.Cut(scanner.Text(), ":")
+
+		if !found {
+			continue
+		}
+
+		field = strings.ToLower(strings.TrimSpace(field))
+		value = strings.TrimSpace(value)
+
+		for _, urlField := range securityTxtFields {
+			if field == urlField && isHttpUrl(value) {
+				entries = append(entries, Entry{Url: value, Source: SecurityTxtSource})
+			}
+		}
+	}
+
+	return entries
+}
+
+type crossDomainPolicy struct {
+	XMLName xml.Name `xml:"cross-domain-policy"`
+	Allow   []struct {
+		Domain string `xml:"domain,attr"`
+	} `xml:"allow-access-from"`
+}
+
+// Root pages of the domains allowed by a Flash cross-domain policy, wildcard domains are ignored
+func ParseCrossDomain(base *url.URL, body []byte) []Entry {
+	var policy crossDomainPolicy
+
+	if err := xml.Unmarshal(body, &policy); err != nil {
+		return nil
+	}
+
+	var entries []Entry
+	for _, allow := range policy.Allow {
+		domain := strings.TrimSpace(allow.Domain)
+
+		if domain == "" || strings.Contains(domain, "*") {
+			continue
+		}
+
+		target := url.URL{Scheme: base.Scheme, Host: domain, Path: "/"}
+		entries = append(entries, Entry{Url: target.String(), Source: CrossDomainSource})
+	}
+
+	return entries
+}
+
+func isHttpUrl(value string) bool {
+	return strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://")
+}
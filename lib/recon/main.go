@@ -0,0 +1,215 @@
+package recon
+
+import (
+	"bloodhound/lib/client"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Where a target was found, used as provenance tag of the targets harvested from each file
+const (
+	RobotsDisallowSource = "robots-disallow"
+	RobotsAllowSource    = "robots-allow"
+	SitemapSource        = "sitemap"
+	SecurityTxtSource    = "security-txt"
+	CrossDomainSource    = "crossdomain"
+)
+
+// Sitemaps can list thousands of pages, harvesting stops at these limits on each host
+const (
+	maxSitemaps    = 10
+	maxSitemapUrls = 500
+)
+
+type Entry struct {
+	Url    string
+	Source string
+
+	// URL of the recon file the entry was found on
+	File string
+}
+
+type Harvester struct {
+	client  *client.BloodhoundClient
+	limiter *client.RateLimiter
+}
+
+func NewHarvester(client *client.BloodhoundClient, limiter *client.RateLimiter) *Harvester {
+	return &Harvester{
+		client:  client,
+		limiter: limiter,
+	}
+}
+
+// Reads robots.txt, sitemaps (the ones listed on robots.txt, or sitemap.xml), security.txt and crossdomain.xml of the origin
+// (scheme and host). Entries are deduplicated, keeping the first source of each URL, disallowed robots entries first.
+// Sitemaps are only requested when in scope, since robots.txt and sitemap indexes often point to other hosts
+func (harvester *Harvester) Harvest(origin string, inScope func(string) bool) []Entry {
+	base, err := url.Parse(strings.TrimSuffix(origin, "/") + "/")
+
+	if err != nil {
+		return nil
+	}
+
+	var entries []Entry
+	seen := make(map[string]bool)
+
+	add := func(found []Entry) {
+		for _, entry := range found {
+			if !seen[entry.Url] {
+				seen[entry.Url] = true
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	var sitemaps []string
+
+	robotsFile := base.JoinPath("robots.txt").String()
+
+	if body, err := harvester.fetch(robotsFile); err == nil {
+		robots, listed := ParseRobots(base, body)
+		add(withFile(robots, robotsFile))
+		sitemaps = listed
+	}
+
+	if len(sitemaps) == 0 {
+		sitemaps = []string{base.JoinPath("sitemap.xml").String()}
+	}
+
+	add(harvester.harvestSitemaps(sitemaps, inScope))
+
+	for _, path := range []string{".well-known/security.txt", "security.txt"} {
+		securityFile := base.JoinPath(path).String()
+
+		if body, err := harvester.fetch(securityFile); err == nil {
+			add(withFile(ParseSecurityTxt(body), securityFile))
+			break
+		}
+	}
+
+	crossDomainFile := base.JoinPath("crossdomain.xml").String()
+
+	if body, err := harvester.fetch(crossDomainFile); err == nil {
+		add(withFile(ParseCrossDomain(base, body), crossDomainFile))
+	}
+
+	log.WithFields(log.Fields{
+		"origin":  origin,
+		"entries": len(entries),
+	}).Debug("Finished host recon")
+
+	return entries
+}
+
+// Follows sitemap indexes breadth first, until every sitemap is read or a limit is reached
+func (harvester *Harvester) harvestSitemaps(queue []string, inScope func(string) bool) []Entry {
+	var entries []Entry
+	visited := make(map[string]bool)
+
+	for len(queue) != 0 && len(visited) < maxSitemaps && len(entries) < maxSitemapUrls {
+		sitemap := queue[0]
+		queue = queue[1:]
+
+		if visited[sitemap] {
+			continue
+		}
+
+		visited[sitemap] = true
+
+		if !inScope(sitemap) {
+			log.WithFields(log.Fields{
+				"sitemap": sitemap,
+			}).Debug("Sitemap is out of scope: Skipping")
+
+			continue
+		}
+
+		body, err := harvester.fetch(sitemap)
+
+		if err != nil {
+			continue
+		}
+
+		pages, nested, err := ParseSitemap(body)
+
+		if err != nil {
+			log.WithFields(log.Fields{
+				"sitemap": sitemap,
+				"err":     err.Error(),
+			}).Debug("Unable to parse sitemap")
+
+			continue
+		}
+
+		for _, page := range pages {
+			if len(entries) == maxSitemapUrls {
+				break
+			}
+
+			entries = append(entries, Entry{Url: page, Source: SitemapSource, File: sitemap})
+		}
+
+		queue = append(queue, nested...)
+	}
+
+	return entries
+}
+
+func withFile(entries []Entry, file string) []Entry {
+	for i := range entries {
+		entries[i].File = file
+	}
+
+	return entries
+}
+
+// Sitemaps are often compressed (sitemap.xml.gz), compressed bodies are decompressed
+func (harvester *Harvester) fetch(target string) ([]byte, error) {
+	harvester.limiter.Wait()
+
+	request, err := http.NewRequest("GET", target, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := harvester.client.Do(request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to read %s, status %d", target, response.StatusCode)
+	}
+
+	body, err := io.ReadAll(response.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.HasPrefix(body, []byte{0x1f, 0x8b}) {
+		reader, err := gzip.NewReader(bytes.NewReader(body))
+
+		if err != nil {
+			return nil, err
+		}
+
+		defer reader.Close()
+
+		return io.ReadAll(reader)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,139 @@
+package recon
+
+import (
+	"bloodhound/lib/client"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseRobots(t *testing.T) {
+	base, _ := url.Parse("https://example.com/")
+
+	robots := `User-agent: *
+Disallow: /admin/ # keep crawlers out
+Disallow: /backup/*.zip
+Disallow: /
+Disallow:
+Allow: /public$
+
+User-agent: Googlebot
+disallow: /internal-search
+Sitemap: https://example.com/sitemap_index.xml`
+
+	entries, sitemaps := ParseRobots(base, []byte(robots))
+
+	expected := []Entry{
+		{Url: "https://example.com/admin/", Source: RobotsDisallowSource},
+		{Url: "https://example.com/backup/", Source: RobotsDisallowSource},
+		{Url: "https://example.com/internal-search", Source: RobotsDisallowSource},
+		{Url: "https://example.com/public", Source: RobotsAllowSource},
+	}
+
+	if !reflect.DeepEqual(expected, entries) {
+		t.Errorf("ParseRobots; want %v; got %v", expected, entries)
+	}
+
+	if !slices.Equal([]string{"https://example.com/sitemap_index.xml"}, sitemaps) {
+		t.Errorf("ParseRobots; want sitemap_index.xml; got %v", sitemaps)
+	}
+
+	t.Run("html page", func(t *testing.T) {
+		if entries, _ := ParseRobots(base, []byte("<!DOCTYPE html><html><body>Disallow: /admin</body></html>")); entries != nil {
+			t.Errorf("ParseRobots; want no entries; got %v", entries)
+		}
+	})
+}
+
+func TestParseSecurityTxt(t *testing.T) {
+	securityTxt := `Contact: mailto:security@example.com
+Contact: https://example.com/security/report
+Policy: https://example.com/security/policy
+Expires: 2030-01-01T00:00:00.000Z`
+
+	expected := []Entry{
+		{Url: "https://example.com/security/report", Source: SecurityTxtSource},
+		{Url: "https://example.com/security/policy", Source: SecurityTxtSource},
+	}
+
+	if entries := ParseSecurityTxt([]byte(securityTxt)); !reflect.DeepEqual(expected, entries) {
+		t.Errorf("ParseSecurityTxt; want %v; got %v", expected, entries)
+	}
+}
+
+func TestParseCrossDomain(t *testing.T) {
+	base, _ := url.Parse("https://example.com/")
+
+	policy := `<?xml version="1.0"?>
+<cross-domain-policy>
+	<allow-access-from domain="*.example.com"/>
+	<allow-access-from domain="partner.example.net"/>
+</cross-domain-policy>`
+
+	expected := []Entry{{Url: "https://partner.example.net/", Source: CrossDomainSource}}
+
+	if entries := ParseCrossDomain(base, []byte(policy)); !reflect.DeepEqual(expected, entries) {
+		t.Errorf("ParseCrossDomain; want %v; got %v", expected, entries)
+	}
+}
+
+func TestHarvest(t *testing.T) {
+	var server *httptest.Server
+
+	// Sitemaps listed on other hosts are out of scope, and should never be requested
+	external := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Harvest; want no request to out of scope host; got %s", r.URL)
+	}))
+	defer external.Close()
+
+	compressed := func(content string) []byte {
+		var buffer bytes.Buffer
+		writer := gzip.NewWriter(&buffer)
+		writer.Write([]byte(content))
+		writer.Close()
+
+		return buffer.Bytes()
+	}
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			fmt.Fprintf(w, "User-agent: *\nDisallow: /staging/\nSitemap: %s/sitemap_index.xml\nSitemap: %s/sitemap.xml\n", server.URL, external.URL)
+		case "/sitemap_index.xml":
+			fmt.Fprintf(w, `<sitemapindex><sitemap><loc>%[1]s/pages.xml.gz</loc></sitemap><sitemap><loc>%[1]s/sitemap_index.xml</loc></sitemap></sitemapindex>`, server.URL)
+		case "/pages.xml.gz":
+			w.Write(compressed(fmt.Sprintf(`<urlset><url><loc>%[1]s/about</loc></url><url><loc>%[1]s/staging/</loc></url></urlset>`, server.URL)))
+		case "/security.txt":
+			fmt.Fprintf(w, "Policy: %s/disclosure\n", server.URL)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	limiter := client.NewRateLimiter(1000)
+	defer limiter.Stop()
+
+	harvester := NewHarvester(client.NewClient(client.ClientConfig{}), limiter)
+
+	expected := []Entry{
+		{Url: server.URL + "/staging/", Source: RobotsDisallowSource, File: server.URL + "/robots.txt"},
+		{Url: server.URL + "/about", Source: SitemapSource, File: server.URL + "/pages.xml.gz"},
+		{Url: server.URL + "/disclosure", Source: SecurityTxtSource, File: server.URL + "/security.txt"},
+	}
+
+	inScope := func(target string) bool {
+		return strings.HasPrefix(target, server.URL+"/")
+	}
+
+	if entries := harvester.Harvest(server.URL, inScope); !reflect.DeepEqual(expected, entries) {
+		t.Errorf("Harvest; want %v; got %v", expected, entries)
+	}
+}
@@ -0,0 +1,44 @@
+package rules
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// Prefix used to reference built-in rulesets instead of a file, e.g. `builtin:auth`
+const BuiltinPrefix = "builtin:"
+
+//go:embed builtin/*.yml
+var builtinFiles embed.FS
+
+func IsBuiltin(name string) bool {
+	return strings.HasPrefix(name, BuiltinPrefix)
+}
+
+// Lists the names of every built-in ruleset, without prefix
+func BuiltinNames() []string {
+	entries, _ := builtinFiles.ReadDir("builtin")
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())))
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// Returns the raw YAML content of a built-in ruleset, accepts names with or without prefix
+func ReadBuiltin(name string) ([]byte, error) {
+	name = strings.TrimPrefix(name, BuiltinPrefix)
+
+	if !slices.Contains(BuiltinNames(), name) {
+		return nil, fmt.Errorf("unknown built-in ruleset '%s', available: %s", name, strings.Join(BuiltinNames(), ", "))
+	}
+
+	return builtinFiles.ReadFile("builtin/" + name + ".yml")
+}
@@ -0,0 +1,35 @@
+package rules
+
+import (
+	"testing"
+)
+
+func TestBuiltinRulesets(t *testing.T) {
+	var names []string
+	for _, name := range BuiltinNames() {
+		names = append(names, BuiltinPrefix+name)
+
+		t.Run(name, func(t *testing.T) {
+			ruleset, err := NewRuleset(BuiltinPrefix + name)
+			if err != nil {
+				t.Fatalf("NewRuleset; unexpected error %s", err)
+			}
+
+			if ruleset.Name == "" || len(ruleset.Rules) == 0 {
+				t.Errorf("NewRuleset; want named ruleset with rules; got %+v", ruleset)
+			}
+		})
+	}
+
+	t.Run("every built-in ruleset together", func(t *testing.T) {
+		if _, err := LoadRulesets(names); err != nil {
+			t.Errorf("LoadRulesets; unexpected error %s", err)
+		}
+	})
+
+	t.Run("unknown built-in ruleset", func(t *testing.T) {
+		if _, err := NewRuleset(BuiltinPrefix + "unknown"); err == nil {
+			t.Error("NewRuleset; want error for unknown built-in ruleset")
+		}
+	})
+}
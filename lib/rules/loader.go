@@ -0,0 +1,222 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Loader merges multiple ruleset files into a single ruleset. Included rulesets are loaded before the rules
+// of the file including them, and overrides are only applied after every file was loaded, so that a program
+// specific ruleset can tweak rules coming from a shared base
+type loader struct {
+	// Files currently being loaded, used to detect include cycles
+	loading []string
+
+	// Files that were already merged, so that shared includes are only loaded once
+	loaded map[string]bool
+
+	names        []string
+	descriptions []string
+	ruleset      Ruleset
+	overrides    []sourcedOverride
+}
+
+type sourcedOverride struct {
+	RuleOverride
+	source string
+}
+
+func LoadRulesets(paths []string) (*Ruleset, error) {
+	loader := &loader{
+		loaded: make(map[string]bool),
+	}
+
+	for _, path := range paths {
+		if err := loader.load(path); err != nil {
+			return &Ruleset{}, err
+		}
+	}
+
+	if err := loader.applyOverrides(); err != nil {
+		return &Ruleset{}, err
+	}
+
+	loader.ruleset.Name = strings.Join(loader.names, ", ")
+	loader.ruleset.Description = strings.Join(loader.descriptions, "; ")
+
+	return &loader.ruleset, nil
+}
+
+func (loader *loader) load(path string) error {
+	if IsBuiltin(path) {
+		return loader.loadBuiltin(path)
+	}
+
+	absolute, err := filepath.Abs(path)
+
+	if err != nil {
+		return fmt.Errorf("unable to resolve ruleset path %q. Reason: %s", path, err.Error())
+	}
+
+	path = absolute
+
+	if slices.Contains(loader.loading, path) {
+		return fmt.Errorf("unable to load ruleset %q. Reason: Include cycle (%s -> %s)", path, strings.Join(loader.loading, " -> "), path)
+	}
+
+	if loader.loaded[path] {
+		return nil
+	}
+
+	info, err := os.Stat(path)
+
+	if err != nil {
+		return fmt.Errorf("unable to open ruleset file. Reason: %s", err.Error())
+	}
+
+	loader.loaded[path] = true
+
+	if info.IsDir() {
+		return loader.loadDirectory(path)
+	}
+
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return fmt.Errorf("unable to open ruleset file. Reason: %s", err.Error())
+	}
+
+	return loader.loadData(path, data)
+}
+
+func (loader *loader) loadBuiltin(name string) error {
+	if loader.loaded[name] {
+		return nil
+	}
+
+	data, err := ReadBuiltin(name)
+
+	if err != nil {
+		return fmt.Errorf("unable to open ruleset. Reason: %s", err.Error())
+	}
+
+	loader.loaded[name] = true
+
+	return loader.loadData(name, data)
+}
+
+func (loader *loader) loadData(path string, data []byte) error {
+	ruleset, err := parseRuleset(path, data)
+
+	if err != nil {
+		return err
+	}
+
+	loader.loading = append(loader.loading, path)
+
+	// Includes are relative to the file that declares them, built-in rulesets can be included from anywhere
+	for _, include := range ruleset.Include {
+		if !IsBuiltin(include) && !filepath.IsAbs(include) {
+			include = filepath.Join(filepath.Dir(path), include)
+		}
+
+		if err := loader.load(include); err != nil {
+			return err
+		}
+	}
+
+	loader.loading = loader.loading[:len(loader.loading)-1]
+
+	return loader.merge(path, ruleset)
+}
+
+func (loader *loader) loadDirectory(path string) error {
+	entries, err := os.ReadDir(path)
+
+	if err != nil {
+		return fmt.Errorf("unable to open ruleset directory. Reason: %s", err.Error())
+	}
+
+	// Entries are sorted by name, so loading order is stable between runs
+	for _, entry := range entries {
+		extension := filepath.Ext(entry.Name())
+
+		if entry.IsDir() || (extension != ".yml" && extension != ".yaml") {
+			continue
+		}
+
+		if err := loader.load(filepath.Join(path, entry.Name())); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (loader *loader) merge(path string, ruleset *Ruleset) error {
+	for _, rule := range ruleset.Rules {
+		index := slices.IndexFunc(loader.ruleset.Rules, func(existing Rule) bool {
+			return existing.Name == rule.Name
+		})
+
+		if index != -1 {
+			return fmt.Errorf("unable to load ruleset %q. Reason: Rule named '%s' is already defined on %q, use overrides to change existing rules",
+				path, rule.Name, loader.ruleset.Rules[index].Source)
+		}
+
+		loader.ruleset.Rules = append(loader.ruleset.Rules, rule)
+	}
+
+	for _, override := range ruleset.Overrides {
+		loader.overrides = append(loader.overrides, sourcedOverride{override, path})
+	}
+
+	if ruleset.Name != "" {
+		loader.names = append(loader.names, ruleset.Name)
+	}
+
+	if ruleset.Description != "" {
+		loader.descriptions = append(loader.descriptions, ruleset.Description)
+	}
+
+	loader.ruleset.Scoring.merge(ruleset.Scoring)
+
+	return nil
+}
+
+func (loader *loader) applyOverrides() error {
+	for _, override := range loader.overrides {
+		index := slices.IndexFunc(loader.ruleset.Rules, func(rule Rule) bool {
+			return rule.Name == override.Name
+		})
+
+		if index == -1 {
+			return fmt.Errorf("unable to apply override from %q. Reason: No rule named '%s' was loaded", override.source, override.Name)
+		}
+
+		if override.Disabled {
+			loader.ruleset.Rules = slices.Delete(loader.ruleset.Rules, index, index+1)
+			continue
+		}
+
+		rule := &loader.ruleset.Rules[index]
+
+		if override.Value != nil {
+			rule.Value = *override.Value
+		}
+
+		if override.Multiplier != nil {
+			// Multipliers scale the final score, negative values would flip the ranking
+			if *override.Multiplier < 0 {
+				return fmt.Errorf("unable to apply override from %q. Reason: Multiplier of rule '%s' can't be negative", override.source, override.Name)
+			}
+
+			rule.Multiplier = *override.Multiplier
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadRulesets(t *testing.T) {
+	base := `
+name: Base
+rules:
+  - name: Is Auth flow?
+    value: 1
+    level: resource
+    content:
+      matches: [login]
+  - name: Has Form?
+    value: 1
+    level: content
+    content:
+      element: form
+`
+
+	program := `
+name: Program
+include:
+  - base.yml
+overrides:
+  - name: Is Auth flow?
+    value: 5
+  - name: Has Form?
+    disabled: true
+rules:
+  - name: Is Admin?
+    value: 3
+    level: resource
+    content:
+      matches: [admin]
+`
+
+	writeFiles := func(t *testing.T, files map[string]string) string {
+		dir := t.TempDir()
+
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		return dir
+	}
+
+	t.Run("include and overrides", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{"base.yml": base, "program.yml": program})
+
+		ruleset, err := NewRuleset(filepath.Join(dir, "program.yml"))
+		if err != nil {
+			t.Fatalf("LoadRulesets; unexpected error %s", err)
+		}
+
+		if len(ruleset.Rules) != 2 {
+			t.Fatalf("LoadRulesets; want 2 rules; got %+v", ruleset.Rules)
+		}
+
+		if ruleset.Rules[0].Name != "Is Auth flow?" || ruleset.Rules[0].Value != 5 {
+			t.Errorf("LoadRulesets; want overridden auth rule; got %+v", ruleset.Rules[0])
+		}
+
+		if ruleset.Rules[1].Name != "Is Admin?" {
+			t.Errorf("LoadRulesets; want admin rule; got %+v", ruleset.Rules[1])
+		}
+	})
+
+	t.Run("multiple files", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{"base.yml": base, "program.yml": program})
+
+		// Base is already included by the program ruleset, and should only be loaded once
+		ruleset, err := LoadRulesets([]string{filepath.Join(dir, "base.yml"), filepath.Join(dir, "program.yml")})
+		if err != nil {
+			t.Fatalf("LoadRulesets; unexpected error %s", err)
+		}
+
+		if ruleset.Name != "Base, Program" || len(ruleset.Rules) != 2 {
+			t.Errorf("LoadRulesets; want merged ruleset; got %+v", ruleset)
+		}
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{"base.yml": base, "program.yml": program, "notes.txt": "ignored"})
+
+		ruleset, err := NewRuleset(dir)
+		if err != nil {
+			t.Fatalf("LoadRulesets; unexpected error %s", err)
+		}
+
+		if len(ruleset.Rules) != 2 {
+			t.Errorf("LoadRulesets; want 2 rules; got %+v", ruleset.Rules)
+		}
+	})
+
+	t.Run("conflicting rule names", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{"base.yml": base, "copy.yml": base})
+
+		_, err := LoadRulesets([]string{filepath.Join(dir, "base.yml"), filepath.Join(dir, "copy.yml")})
+		if err == nil || !strings.Contains(err.Error(), "'Is Auth flow?' is already defined") {
+			t.Errorf("LoadRulesets; want conflict error; got %v", err)
+		}
+	})
+
+	t.Run("override of unknown rule", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{"program.yml": strings.Replace(program, "include:\n  - base.yml\n", "", 1)})
+
+		_, err := NewRuleset(filepath.Join(dir, "program.yml"))
+		if err == nil || !strings.Contains(err.Error(), "No rule named") {
+			t.Errorf("LoadRulesets; want unknown rule error; got %v", err)
+		}
+	})
+
+	t.Run("negative override multiplier", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{
+			"base.yml":    base,
+			"program.yml": "include: [base.yml]\noverrides:\n  - name: Has Form?\n    multiplier: -1\n",
+		})
+
+		_, err := NewRuleset(filepath.Join(dir, "program.yml"))
+		if err == nil || !strings.Contains(err.Error(), "can't be negative") {
+			t.Errorf("LoadRulesets; want negative multiplier error; got %v", err)
+		}
+	})
+
+	t.Run("later ruleset turns normalization off", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{
+			"base.yml":    "scoring:\n  normalize: true\n" + base,
+			"program.yml": "include: [base.yml]\nscoring:\n  normalize: false\n",
+		})
+
+		ruleset, err := NewRuleset(filepath.Join(dir, "program.yml"))
+		if err != nil {
+			t.Fatalf("LoadRulesets; unexpected error %s", err)
+		}
+
+		if ruleset.Scoring.IsNormalized() {
+			t.Errorf("LoadRulesets; want normalization off; got %+v", ruleset.Scoring)
+		}
+	})
+
+	t.Run("include cycle", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{
+			"a.yml": "include: [b.yml]\n",
+			"b.yml": "include: [a.yml]\n",
+		})
+
+		_, err := NewRuleset(filepath.Join(dir, "a.yml"))
+		if err == nil || !strings.Contains(err.Error(), "Include cycle") {
+			t.Errorf("LoadRulesets; want cycle error; got %v", err)
+		}
+	})
+}
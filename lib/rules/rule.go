@@ -1,17 +1,138 @@
 package rules
 
+import (
+	"bloodhound/lib/jsonpath"
+	"slices"
+)
+
 type RuleContent struct {
 	Element string
 	Attr    map[string]string
 	Matches []string
+
+	// Detected technology name, and an optional version constraint (e.g. "< 6", "1.x"), for tech level rules
+	Technology string
+	Version    string
+
+	// Type of detected secret (e.g. aws-access-key), or * for any type, for finding level rules
+	Finding string
+
+	// Conditions a single form of the page must satisfy, for form level rules
+	Form *FormCondition
+
+	// Conditions a single reflected parameter must satisfy, for reflection level rules
+	Reflection *ReflectionCondition
+
+	// Conditions a single discovered parameter must satisfy, for parameter level rules
+	Parameter *ParameterCondition
+
+	// Conditions a confirmed GraphQL endpoint must satisfy, for graphql level rules
+	Graphql *GraphqlCondition
+
+	// Conditions the body of a JSON response must satisfy, for json level rules
+	Json *JsonCondition
+
+	// Conditions on how the target was found, for provenance level rules
+	Provenance *ProvenanceCondition
+}
+
+// Unset conditions are ignored, an empty condition matches any reflection
+type ReflectionCondition struct {
+	// Where the parameter is reflected: text, attribute, url, script, style, comment, header or body
+	Context string
+
+	// Whether special characters must (or must not) be reflected without encoding
+	Unescaped *bool
+}
+
+// Unset conditions are ignored, an empty condition matches any discovered parameter
+type ParameterCondition struct {
+	// Parameter name must contain any of these words
+	Names []string
+
+	// Why the parameter was considered accepted: reflected or "changes response"
+	Reason string
+
+	// Where the name came from: wordlist, form or script
+	Source string
+}
+
+// Unset conditions are ignored, an empty condition matches any confirmed endpoint
+type GraphqlCondition struct {
+	// Whether introspection must (or must not) be enabled
+	Introspection *bool
+
+	// Minimum number of mutations of the schema
+	Mutations int
+
+	// Schema must have a field (e.g. User.password) containing any of these words, ignoring case
+	Fields []string
+}
+
+// Origin of a form action compared to the page it's on
+const (
+	SameOrigin  = "same"
+	CrossOrigin = "cross"
+)
+
+type ProvenanceCondition struct {
+	// Target must have been found on any of these kinds of sources (e.g. robots-disallow, script)
+	Sources []string
+
+	// Every way the target was found must be one of the sources (e.g. only referenced from scripts, never linked)
+	Only bool
+}
+
+// Unset conditions are ignored, an empty condition matches any JSON response
+type JsonCondition struct {
+	// JSONPath subset ($.users[*].id, $..password), at least one node must be found
+	Path string
+
+	// Value a found node must have, written like JSON scalars without quotes (false, 0, admin)
+	Equals *string
+
+	// Key names containing any of these words must be found, below the nodes found by path
+	Keys []string
+}
+
+// Unset conditions are ignored, so a rule only describes what it cares about
+type FormCondition struct {
+	Method  string
+	Enctype string
+	Origin  string
+
+	// Whether the form must (or must not) have an anti-CSRF token field
+	Csrf *bool
+
+	// Field types the form must have, every one of them (e.g. file, password, email)
+	Fields []string
 }
 
+type Severity string
+
+const (
+	UnknownSeverity  Severity = ""
+	InfoSeverity     Severity = "info"
+	LowSeverity      Severity = "low"
+	MediumSeverity   Severity = "medium"
+	HighSeverity     Severity = "high"
+	CriticalSeverity Severity = "critical"
+)
+
 type Rule struct {
-	Name    string
-	Value   int
-	Level   Level
-	Remove  bool
-	Content RuleContent
+	Name        string
+	Description string
+	Value       float64
+	Multiplier  float64
+	Category    string
+	Severity    Severity
+	Tags        []string
+	Level       Level
+	Remove      bool
+	Content     RuleContent
+
+	// Ruleset file the rule was loaded from
+	Source string `yaml:"-"`
 }
 
 func NewMatchRuleContent(matches []string) RuleContent {
@@ -27,15 +148,15 @@ func NewElementRuleContent(element string, attr map[string]string) RuleContent {
 	}
 }
 
-func NewResourceRule(name string, value int, remove bool, content RuleContent) Rule {
+func NewResourceRule(name string, value float64, remove bool, content RuleContent) Rule {
 	return NewRule(name, ResourceLevel, value, remove, content)
 }
 
-func NewContentRule(name string, value int, remove bool, content RuleContent) Rule {
+func NewContentRule(name string, value float64, remove bool, content RuleContent) Rule {
 	return NewRule(name, ContentLevel, value, remove, content)
 }
 
-func NewRule(name string, level Level, value int, remove bool, content RuleContent) Rule {
+func NewRule(name string, level Level, value float64, remove bool, content RuleContent) Rule {
 	return Rule{
 		Name:    name,
 		Level:   level,
@@ -46,11 +167,49 @@ func NewRule(name string, level Level, value int, remove bool, content RuleConte
 }
 
 func (rule *Rule) isValid() bool {
+	// Multipliers scale the final score, negative values would flip the ranking
+	if rule.Multiplier < 0 {
+		return false
+	}
+
+	switch rule.Severity {
+	case UnknownSeverity, InfoSeverity, LowSeverity, MediumSeverity, HighSeverity, CriticalSeverity:
+	default:
+		return false
+	}
+
 	switch rule.Level {
 	case ResourceLevel:
 		return rule.isResourceRuleValid()
 	case ContentLevel:
 		return rule.isContentRuleValid()
+	case TechLevel:
+		return rule.isTechRuleValid()
+	case FindingLevel:
+		return rule.isFindingRuleValid()
+	case FormLevel:
+		return rule.isFormRuleValid()
+	case ReflectionLevel:
+		return rule.Content.Reflection != nil
+	case ParameterLevel:
+		return rule.Content.Parameter != nil
+	case GraphqlLevel:
+		return rule.Content.Graphql != nil && rule.Content.Graphql.Mutations >= 0
+	case JsonLevel:
+		return rule.isJsonRuleValid()
+	case ProvenanceLevel:
+		return rule.Content.Provenance != nil && len(rule.Content.Provenance.Sources) != 0
+	}
+
+	return false
+}
+
+// Category is treated as an implicit tag, so that whole categories can be selected by name
+func (rule *Rule) HasAnyTag(tags []string) bool {
+	for _, tag := range tags {
+		if tag == rule.Category || slices.Contains(rule.Tags, tag) {
+			return true
+		}
 	}
 
 	return false
@@ -63,3 +222,41 @@ func (rule *Rule) isResourceRuleValid() bool {
 func (rule *Rule) isContentRuleValid() bool {
 	return len(rule.Content.Matches) != 0 || rule.Content.Element != ""
 }
+
+func (rule *Rule) isTechRuleValid() bool {
+	return rule.Content.Technology != ""
+}
+
+func (rule *Rule) isFindingRuleValid() bool {
+	return rule.Content.Finding != ""
+}
+
+// Values can only be compared on nodes found by a valid path
+func (rule *Rule) isJsonRuleValid() bool {
+	condition := rule.Content.Json
+
+	if condition == nil {
+		return false
+	}
+
+	if condition.Path == "" {
+		return condition.Equals == nil
+	}
+
+	_, err := jsonpath.Compile(condition.Path)
+
+	return err == nil
+}
+
+func (rule *Rule) isFormRuleValid() bool {
+	if rule.Content.Form == nil {
+		return false
+	}
+
+	switch rule.Content.Form.Origin {
+	case "", SameOrigin, CrossOrigin:
+		return true
+	}
+
+	return false
+}
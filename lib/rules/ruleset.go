@@ -2,7 +2,6 @@ package rules
 
 import (
 	"fmt"
-	"os"
 
 	"gopkg.in/yaml.v3"
 )
@@ -10,33 +9,64 @@ import (
 type Level string
 
 const (
-	UnknownLevel  Level = ""
-	ResourceLevel Level = "resource"
-	ContentLevel  Level = "content"
+	UnknownLevel    Level = ""
+	ResourceLevel   Level = "resource"
+	ContentLevel    Level = "content"
+	TechLevel       Level = "tech"
+	FindingLevel    Level = "finding"
+	FormLevel       Level = "form"
+	ReflectionLevel Level = "reflection"
+	ParameterLevel  Level = "parameter"
+	GraphqlLevel    Level = "graphql"
+	JsonLevel       Level = "json"
+	ProvenanceLevel Level = "provenance"
 )
 
 type Ruleset struct {
-	Name  string
-	Rules []Rule
+	Name        string
+	Description string
+	Include     []string
+	Overrides   []RuleOverride
+	Scoring     ScoringConfig
+	Rules       []Rule
 }
 
-func NewRuleset(filepath string) (*Ruleset, error) {
-	data, err := os.ReadFile(filepath)
+// Override changes a rule defined on another ruleset, referencing it by name
+type RuleOverride struct {
+	Name       string
+	Disabled   bool
+	Value      *float64
+	Multiplier *float64
+}
 
-	if err != nil {
-		return &Ruleset{}, fmt.Errorf("unable to open ruleset file. Reason: %s", err.Error())
-	}
+// Loads a single ruleset file, together with all the rulesets it includes
+func NewRuleset(path string) (*Ruleset, error) {
+	return LoadRulesets([]string{path})
+}
 
+func parseRuleset(path string, data []byte) (*Ruleset, error) {
 	ruleset := Ruleset{}
-	err = yaml.Unmarshal(data, &ruleset)
+	err := yaml.Unmarshal(data, &ruleset)
 
 	if err != nil {
-		return &Ruleset{}, fmt.Errorf("unable to parse ruleset file. Reason: %s", err.Error())
+		return &Ruleset{}, fmt.Errorf("unable to parse ruleset file %q. Reason: %s", path, err.Error())
 	}
 
-	for _, rule := range ruleset.Rules {
+	if !ruleset.Scoring.isValid() {
+		return &Ruleset{}, fmt.Errorf("unable to parse scoring configurations on %q. Reason: Unknown model '%s' or negative category settings", path, ruleset.Scoring.Model)
+	}
+
+	for i, rule := range ruleset.Rules {
 		if !rule.isValid() {
-			return &Ruleset{}, fmt.Errorf("unable to parse rule named '%s'. Reason: Invalid rule configurations", rule.Name)
+			return &Ruleset{}, fmt.Errorf("unable to parse rule named '%s' on %q. Reason: Invalid rule configurations", rule.Name, path)
+		}
+
+		ruleset.Rules[i].Source = path
+	}
+
+	for _, override := range ruleset.Overrides {
+		if override.Name == "" {
+			return &Ruleset{}, fmt.Errorf("unable to parse overrides on %q. Reason: Override without rule name", path)
 		}
 	}
 
@@ -55,3 +85,21 @@ func (ruleset *Ruleset) GetRules(level Level) []Rule {
 
 	return result
 }
+
+// Keeps rules with any of the included tags (or every rule if none is included), and none of the excluded ones
+func (ruleset *Ruleset) FilterTags(include []string, exclude []string) {
+	var result []Rule
+	for _, rule := range ruleset.Rules {
+		if len(include) != 0 && !rule.HasAnyTag(include) {
+			continue
+		}
+
+		if rule.HasAnyTag(exclude) {
+			continue
+		}
+
+		result = append(result, rule)
+	}
+
+	ruleset.Rules = result
+}
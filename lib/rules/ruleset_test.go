@@ -0,0 +1,62 @@
+package rules
+
+import (
+	"testing"
+)
+
+func TestFilterTags(t *testing.T) {
+	login := NewResourceRule("Is login", 1, false, NewMatchRuleContent([]string{"login"}))
+	login.Category = "auth"
+
+	upload := NewContentRule("Has file upload", 1, false, NewElementRuleContent("input", map[string]string{"type": "file"}))
+	upload.Category = "upload"
+	upload.Tags = []string{"rce"}
+
+	debug := NewResourceRule("Is debug", 1, false, NewMatchRuleContent([]string{"debug"}))
+	debug.Tags = []string{"noisy"}
+
+	assert := func(t *testing.T, expected []string, ruleset Ruleset) {
+		var actual []string
+		for _, rule := range ruleset.Rules {
+			actual = append(actual, rule.Name)
+		}
+
+		if len(expected) != len(actual) {
+			t.Fatalf("FilterTags; want %v; got %v", expected, actual)
+		}
+
+		for i := range expected {
+			if expected[i] != actual[i] {
+				t.Errorf("FilterTags; want %v; got %v", expected, actual)
+			}
+		}
+	}
+
+	newRuleset := func() Ruleset {
+		return Ruleset{Rules: []Rule{login, upload, debug}}
+	}
+
+	t.Run("no filters", func(t *testing.T) {
+		ruleset := newRuleset()
+		ruleset.FilterTags(nil, nil)
+		assert(t, []string{"Is login", "Has file upload", "Is debug"}, ruleset)
+	})
+
+	t.Run("include by category", func(t *testing.T) {
+		ruleset := newRuleset()
+		ruleset.FilterTags([]string{"auth", "upload"}, nil)
+		assert(t, []string{"Is login", "Has file upload"}, ruleset)
+	})
+
+	t.Run("include by tag", func(t *testing.T) {
+		ruleset := newRuleset()
+		ruleset.FilterTags([]string{"rce"}, nil)
+		assert(t, []string{"Has file upload"}, ruleset)
+	})
+
+	t.Run("exclude by tag", func(t *testing.T) {
+		ruleset := newRuleset()
+		ruleset.FilterTags(nil, []string{"noisy"})
+		assert(t, []string{"Is login", "Has file upload"}, ruleset)
+	})
+}
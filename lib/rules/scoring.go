@@ -0,0 +1,62 @@
+package rules
+
+const (
+	AdditiveScoring = "additive"
+	WeightedScoring = "weighted"
+)
+
+type ScoringConfig struct {
+	// Scoring model used to combine matched rules, defaults to additive
+	Model string
+
+	// Normalize scores to the 0-100 range, relative to the maximum score the ruleset can produce.
+	// Nil when not set, so a later ruleset can turn normalization off
+	Normalize *bool
+
+	// Weight and cap per rule category, only used by the weighted model
+	Categories map[string]CategoryConfig
+}
+
+type CategoryConfig struct {
+	Weight float64
+	Cap    float64
+}
+
+func (config *ScoringConfig) isValid() bool {
+	switch config.Model {
+	case "", AdditiveScoring, WeightedScoring:
+	default:
+		return false
+	}
+
+	for _, category := range config.Categories {
+		if category.Weight < 0 || category.Cap < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (config *ScoringConfig) IsNormalized() bool {
+	return config.Normalize != nil && *config.Normalize
+}
+
+// Settings from later rulesets take precedence over the ones they include
+func (config *ScoringConfig) merge(other ScoringConfig) {
+	if other.Model != "" {
+		config.Model = other.Model
+	}
+
+	if other.Normalize != nil {
+		config.Normalize = other.Normalize
+	}
+
+	for name, category := range other.Categories {
+		if config.Categories == nil {
+			config.Categories = make(map[string]CategoryConfig)
+		}
+
+		config.Categories[name] = category
+	}
+}
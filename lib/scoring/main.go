@@ -0,0 +1,147 @@
+package scoring
+
+import (
+	"bloodhound/lib/rules"
+	"math"
+)
+
+// Model combines the rules matched by a single target into its final score
+type Model interface {
+	Score(matched []rules.Rule) float64
+}
+
+func NewModel(config rules.ScoringConfig, ruleList []rules.Rule) Model {
+	var model Model
+
+	switch config.Model {
+	case rules.WeightedScoring:
+		model = NewWeightedModel(config.Categories)
+	default:
+		model = NewAdditiveModel()
+	}
+
+	if config.IsNormalized() {
+		return NewNormalizedModel(model, ruleList)
+	}
+
+	return model
+}
+
+// Additive model sums the value of every matched rule, and then applies multiplicative boosts on top of the sum
+type AdditiveModel struct{}
+
+func NewAdditiveModel() *AdditiveModel {
+	return &AdditiveModel{}
+}
+
+func (model *AdditiveModel) Score(matched []rules.Rule) float64 {
+	score := 0.0
+
+	for _, rule := range matched {
+		score += rule.Value
+	}
+
+	return score * multiplier(matched)
+}
+
+// Weighted model sums rule values per category, scales each category by its weight and caps it,
+// so that many low value rules on the same category can't drown out a single critical signal from another one
+type WeightedModel struct {
+	categories map[string]rules.CategoryConfig
+}
+
+func NewWeightedModel(categories map[string]rules.CategoryConfig) *WeightedModel {
+	return &WeightedModel{
+		categories: categories,
+	}
+}
+
+func (model *WeightedModel) Score(matched []rules.Rule) float64 {
+	totals := make(map[string]float64)
+
+	for _, rule := range matched {
+		totals[rule.Category] += rule.Value
+	}
+
+	score := 0.0
+
+	for category, total := range totals {
+		config := model.categories[category]
+
+		// Categories without an explicit weight count as-is
+		weight := config.Weight
+		if weight == 0 {
+			weight = 1
+		}
+
+		total *= weight
+
+		if config.Cap > 0 {
+			total = math.Min(total, config.Cap)
+		}
+
+		score += total
+	}
+
+	return score * multiplier(matched)
+}
+
+// Normalized model maps the score of another model into the 0-100 range, using the score of a target
+// that matches every positive rule as the maximum. This makes scores comparable across runs and rulesets
+type NormalizedModel struct {
+	model Model
+	max   float64
+}
+
+func NewNormalizedModel(model Model, ruleList []rules.Rule) *NormalizedModel {
+	var positive []rules.Rule
+
+	for _, rule := range ruleList {
+		if rule.Remove {
+			continue
+		}
+
+		// Rules that can only lower the score are not part of the best case
+		if rule.Value <= 0 && rule.Multiplier <= 1 {
+			continue
+		}
+
+		if rule.Multiplier != 0 && rule.Multiplier < 1 {
+			rule.Multiplier = 0
+		}
+
+		if rule.Value < 0 {
+			rule.Value = 0
+		}
+
+		positive = append(positive, rule)
+	}
+
+	return &NormalizedModel{
+		model: model,
+		max:   model.Score(positive),
+	}
+}
+
+func (model *NormalizedModel) Score(matched []rules.Rule) float64 {
+	if model.max <= 0 {
+		return 0
+	}
+
+	score := 100 * model.model.Score(matched) / model.max
+
+	return math.Max(0, math.Min(100, score))
+}
+
+func multiplier(matched []rules.Rule) float64 {
+	result := 1.0
+
+	for _, rule := range matched {
+		// Rules without a multiplier don't affect the result
+		if rule.Multiplier != 0 {
+			result *= rule.Multiplier
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,84 @@
+package scoring
+
+import (
+	"bloodhound/lib/rules"
+	"testing"
+)
+
+func TestScore(t *testing.T) {
+	login := rules.NewResourceRule("Is login", 1, false, rules.NewMatchRuleContent([]string{"login"}))
+	login.Category = "auth"
+
+	logout := rules.NewResourceRule("Is logout", 1, false, rules.NewMatchRuleContent([]string{"logout"}))
+	logout.Category = "auth"
+
+	upload := rules.NewContentRule("Has file upload", 4, false, rules.NewElementRuleContent("input", map[string]string{"type": "file"}))
+	upload.Category = "upload"
+
+	staging := rules.NewResourceRule("Is staging", 0, false, rules.NewMatchRuleContent([]string{"staging."}))
+	staging.Multiplier = 2
+
+	ruleList := []rules.Rule{login, logout, upload, staging}
+
+	assert := func(t *testing.T, expected float64, actual float64) {
+		if expected != actual {
+			t.Errorf("Score; want %v; got %v", expected, actual)
+		}
+	}
+
+	t.Run("additive model", func(t *testing.T) {
+		model := NewModel(rules.ScoringConfig{}, ruleList)
+
+		t.Run("no matched rules", func(t *testing.T) {
+			assert(t, 0, model.Score(nil))
+		})
+
+		t.Run("sums matched rules", func(t *testing.T) {
+			assert(t, 6, model.Score([]rules.Rule{login, logout, upload}))
+		})
+
+		t.Run("applies multiplier", func(t *testing.T) {
+			assert(t, 10, model.Score([]rules.Rule{login, upload, staging}))
+		})
+	})
+
+	t.Run("weighted model", func(t *testing.T) {
+		model := NewModel(rules.ScoringConfig{
+			Model: rules.WeightedScoring,
+			Categories: map[string]rules.CategoryConfig{
+				"auth":   {Weight: 3, Cap: 4},
+				"upload": {Weight: 2},
+			},
+		}, ruleList)
+
+		t.Run("applies category weight", func(t *testing.T) {
+			assert(t, 3, model.Score([]rules.Rule{login}))
+		})
+
+		t.Run("caps category total", func(t *testing.T) {
+			assert(t, 4, model.Score([]rules.Rule{login, logout}))
+		})
+
+		t.Run("sums categories and applies multiplier", func(t *testing.T) {
+			assert(t, 24, model.Score([]rules.Rule{login, logout, upload, staging}))
+		})
+	})
+
+	t.Run("normalized model", func(t *testing.T) {
+		normalize := true
+		model := NewModel(rules.ScoringConfig{Normalize: &normalize}, ruleList)
+
+		t.Run("every rule matched", func(t *testing.T) {
+			assert(t, 100, model.Score(ruleList))
+		})
+
+		t.Run("partial match", func(t *testing.T) {
+			assert(t, 50, model.Score([]rules.Rule{login, logout, upload}))
+		})
+
+		t.Run("ruleset without positive rules", func(t *testing.T) {
+			model := NewModel(rules.ScoringConfig{Normalize: &normalize}, nil)
+			assert(t, 0, model.Score([]rules.Rule{login}))
+		})
+	})
+}
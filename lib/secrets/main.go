@@ -0,0 +1,193 @@
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Minimum Shannon entropy (bits per character) of values assigned to secret looking names
+const minimumEntropy = 3.5
+
+type Finding struct {
+	Type string `json:"type"`
+
+	// Where the secret was found (e.g. script, attribute href, json $.config.key)
+	Location string `json:"location"`
+
+	// Secret with most of its characters masked, the full value is never stored
+	Preview string `json:"preview"`
+}
+
+// Scans text, attributes, comments and inline scripts of a document, or every string of JSON bodies.
+// Each secret is only reported once per response, on the first location it was found on
+func Scan(body []byte, document *html.Node) []Finding {
+	scanner := newScanner()
+
+	if isJson(body) {
+		var data any
+		if err := json.Unmarshal(body, &data); err == nil {
+			scanner.scanJson("$", "", data)
+			return scanner.findings
+		}
+	}
+
+	if document == nil {
+		scanner.scan(string(body), "body")
+		return scanner.findings
+	}
+
+	for node := range document.Descendants() {
+		switch node.Type {
+		case html.TextNode:
+			location := "text"
+			if node.Parent != nil && node.Parent.Type == html.ElementNode && node.Parent.Data == "script" {
+				location = "script"
+			}
+
+			scanner.scan(node.Data, location)
+		case html.CommentNode:
+			scanner.scan(node.Data, "comment")
+		case html.ElementNode:
+			for _, attr := range node.Attr {
+				scanner.scan(attr.Val, "attribute "+attr.Key)
+			}
+		}
+	}
+
+	return scanner.findings
+}
+
+// Masks everything but the first 4 and last 2 characters, short values are fully masked
+func Redact(value string) string {
+	if len(value) <= 8 {
+		return strings.Repeat("*", len(value))
+	}
+
+	return value[:4] + strings.Repeat("*", min(len(value)-6, 16)) + value[len(value)-2:]
+}
+
+type scanner struct {
+	findings []Finding
+	seen     map[string]bool
+}
+
+func newScanner() *scanner {
+	return &scanner{
+		seen: make(map[string]bool),
+	}
+}
+
+func (scanner *scanner) add(secretType string, location string, value string) {
+	key := secretType + "\x00" + value
+	if scanner.seen[key] {
+		return
+	}
+
+	scanner.seen[key] = true
+
+	preview := Redact(value)
+
+	// Key blocks are reported by their header, which isn't secret by itself
+	if secretType == PrivateKey || secretType == GcpServiceAccount {
+		preview = value
+	}
+
+	scanner.findings = append(scanner.findings, Finding{
+		Type:     secretType,
+		Location: location,
+		Preview:  preview,
+	})
+}
+
+func (scanner *scanner) scan(content string, location string) {
+	if len(content) < 16 {
+		return
+	}
+
+	found := make(map[string]bool)
+
+	for _, pattern := range patterns {
+		for _, match := range pattern.Expression.FindAllStringSubmatch(content, -1) {
+			scanner.add(pattern.Type, location, match[pattern.Group])
+			found[match[pattern.Group]] = true
+		}
+	}
+
+	// Generic secrets are only reported when no specific pattern matched the same value
+	for _, match := range assignment.FindAllStringSubmatch(content, -1) {
+		if value := match[2]; !found[value] && !containsKnown(value, found) && entropy(value) >= minimumEntropy {
+			scanner.add(GenericSecret, location, value)
+		}
+	}
+}
+
+func (scanner *scanner) scanJson(path string, key string, data any) {
+	switch value := data.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+
+		// Map iteration order is random, sorting keeps findings stable between runs
+		sort.Strings(keys)
+
+		// Whole objects are scanned too, since some secrets span multiple keys (e.g. service accounts)
+		if encoded, err := json.Marshal(value); err == nil && serviceAccount.Match(encoded) {
+			scanner.add(GcpServiceAccount, "json "+path, serviceAccount.FindString(string(encoded)))
+		}
+
+		for _, key := range keys {
+			scanner.scanJson(path+"."+key, key, value[key])
+		}
+	case []any:
+		for i, item := range value {
+			scanner.scanJson(fmt.Sprintf("%s[%d]", path, i), key, item)
+		}
+	case string:
+		before := len(scanner.findings)
+		scanner.scan(value, "json "+path)
+
+		if len(scanner.findings) == before && secretName.MatchString(key) && len(value) >= 16 && entropy(value) >= minimumEntropy {
+			scanner.add(GenericSecret, "json "+path, value)
+		}
+	}
+}
+
+func containsKnown(value string, known map[string]bool) bool {
+	for secret := range known {
+		if strings.Contains(value, secret) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isJson(body []byte) bool {
+	trimmed := strings.TrimSpace(string(body))
+	return strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")
+}
+
+// Shannon entropy in bits per character, random keys are usually above 4 while words and paths stay below 3.5
+func entropy(value string) float64 {
+	counts := make(map[rune]int)
+	for _, char := range value {
+		counts[char]++
+	}
+
+	var result float64
+	length := float64(len([]rune(value)))
+
+	for _, count := range counts {
+		probability := float64(count) / length
+		result -= probability * math.Log2(probability)
+	}
+
+	return result
+}
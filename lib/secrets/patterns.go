@@ -0,0 +1,50 @@
+package secrets
+
+import "regexp"
+
+type pattern struct {
+	Type       string
+	Expression *regexp.Regexp
+
+	// Capture group holding the secret, 0 when it's the whole match
+	Group int
+}
+
+// Types of detected secrets, used by finding rules
+const (
+	AwsAccessKey         = "aws-access-key"
+	AwsSecretKey         = "aws-secret-key"
+	GcpApiKey            = "gcp-api-key"
+	GcpServiceAccount    = "gcp-service-account"
+	StripeSecretKey      = "stripe-secret-key"
+	StripePublishableKey = "stripe-publishable-key"
+	SlackToken           = "slack-token"
+	SlackWebhook         = "slack-webhook"
+	GithubToken          = "github-token"
+	Jwt                  = "jwt"
+	PrivateKey           = "private-key"
+	GenericSecret        = "generic-secret"
+)
+
+// Service account key files are JSON objects, so JSON bodies are matched on their encoded objects too
+var serviceAccount = regexp.MustCompile(`"type"\s*:\s*"service_account"`)
+
+var patterns = []pattern{
+	{AwsAccessKey, regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`), 0},
+	{AwsSecretKey, regexp.MustCompile(`(?i)aws_?secret_?(?:access_?)?key["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})\b`), 1},
+	{GcpApiKey, regexp.MustCompile(`\bAIza[0-9A-Za-z_\-]{35}\b`), 0},
+	{GcpServiceAccount, serviceAccount, 0},
+	{StripeSecretKey, regexp.MustCompile(`\b[rs]k_live_[0-9A-Za-z]{24,}\b`), 0},
+	{StripePublishableKey, regexp.MustCompile(`\bpk_live_[0-9A-Za-z]{24,}\b`), 0},
+	{SlackToken, regexp.MustCompile(`\bxox[abposr]-[0-9A-Za-z\-]{10,}\b`), 0},
+	{SlackWebhook, regexp.MustCompile(`https://hooks\.slack\.com/services/T[0-9A-Za-z]+/B[0-9A-Za-z]+/[0-9A-Za-z]+`), 0},
+	{GithubToken, regexp.MustCompile(`\bgh[pousr]_[0-9A-Za-z]{36}\b`), 0},
+	{Jwt, regexp.MustCompile(`\beyJ[0-9A-Za-z_\-]{10,}\.eyJ[0-9A-Za-z_\-]{10,}\.[0-9A-Za-z_\-]{10,}`), 0},
+	{PrivateKey, regexp.MustCompile(`-----BEGIN (?:RSA |EC |DSA |OPENSSH |PGP |ENCRYPTED )?PRIVATE KEY(?: BLOCK)?-----`), 0},
+}
+
+// Values assigned to secret looking names, only reported when they look random enough
+var assignment = regexp.MustCompile(`(?i)["']?([a-z0-9_\-]*(?:api_?key|apikey|secret|token|passw(?:or)?d|credential|auth)[a-z0-9_\-]*)["']?\s*[:=]\s*["']([^"'\s]{16,})["']`)
+
+// Names that are usually assigned secrets, used for JSON keys
+var secretName = regexp.MustCompile(`(?i)(?:api_?key|apikey|secret|token|passw(?:or)?d|credential|private_?key)`)
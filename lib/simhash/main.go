@@ -0,0 +1,73 @@
+package simhash
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"strings"
+)
+
+const (
+	// Number of consecutive words used as a single feature
+	shingleSize = 3
+
+	// Fingerprints are considered near-duplicates up to this distance
+	SimilarDistance = 3
+)
+
+// Simhash is a locality sensitive hash, similar documents produce fingerprints that differ on few bits,
+// so the similarity of two documents can be measured by the Hamming distance of their fingerprints
+func Simhash(features []string) uint64 {
+	var weights [64]int
+
+	for _, feature := range features {
+		hash := fnv.New64a()
+		hash.Write([]byte(feature))
+		sum := hash.Sum64()
+
+		for bit := range 64 {
+			if sum&(1<<bit) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+
+	var fingerprint uint64
+	for bit := range 64 {
+		if weights[bit] > 0 {
+			fingerprint |= 1 << bit
+		}
+	}
+
+	return fingerprint
+}
+
+// Fingerprints text using shingles of lowercase words as features
+func Text(content []byte) uint64 {
+	return Simhash(Shingles(strings.ToLower(string(content))))
+}
+
+func Shingles(content string) []string {
+	words := strings.Fields(content)
+
+	if len(words) < shingleSize {
+		return words
+	}
+
+	var shingles []string
+	for i := 0; i+shingleSize <= len(words); i++ {
+		shingles = append(shingles, strings.Join(words[i:i+shingleSize], " "))
+	}
+
+	return shingles
+}
+
+// Number of different bits between two fingerprints
+func Distance(a uint64, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+func Similar(a uint64, b uint64) bool {
+	return Distance(a, b) <= SimilarDistance
+}
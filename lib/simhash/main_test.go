@@ -0,0 +1,37 @@
+package simhash
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSimhash(t *testing.T) {
+	assert := func(t testing.TB, expected bool, actual bool) {
+		t.Helper()
+		if expected != actual {
+			t.Errorf("Similar; want %v; got %v", expected, actual)
+		}
+	}
+
+	page := strings.Repeat("Sorry, the page you are looking for could not be found on this server. ", 20)
+
+	t.Run("identical content is similar", func(t *testing.T) {
+		assert(t, true, Similar(Text([]byte(page)), Text([]byte(page))))
+	})
+
+	t.Run("content differing on a few words is similar", func(t *testing.T) {
+		changed := page + " Requested path: /a8f3e9c2"
+		assert(t, true, Similar(Text([]byte(page)), Text([]byte(changed))))
+	})
+
+	t.Run("unrelated content is not similar", func(t *testing.T) {
+		other := "Welcome to the administration panel, please sign in with your username and password to manage users, orders and products"
+		assert(t, false, Similar(Text([]byte(page)), Text([]byte(other))))
+	})
+
+	t.Run("distance of equal fingerprints is zero", func(t *testing.T) {
+		if distance := Distance(42, 42); distance != 0 {
+			t.Errorf("Distance; want %v; got %v", 0, distance)
+		}
+	})
+}
@@ -0,0 +1,204 @@
+package tech
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+type Technology struct {
+	Name     string `json:"name"`
+	Category string `json:"category,omitempty"`
+
+	// Empty when the version isn't visible on the response
+	Version string `json:"version,omitempty"`
+
+	// What the technology was detected from (e.g. header Server, script src)
+	Evidence string `json:"evidence,omitempty"`
+}
+
+func (technology Technology) String() string {
+	if technology.Version == "" {
+		return technology.Name
+	}
+
+	return technology.Name + " " + technology.Version
+}
+
+// Detects technologies from the response headers, cookies, meta tags, script sources and markup.
+// Technologies are listed in signature order, each one only once, keeping the first visible version
+func Detect(header http.Header, body []byte, document *html.Node) []Technology {
+	cookies := parseCookies(header)
+	meta, scripts := parseDocument(document)
+
+	detected := make(map[string]Technology)
+
+	for _, signature := range signatures {
+		technology, found := signature.detect(header, cookies, meta, scripts, body)
+
+		if found {
+			detected[signature.Name] = technology
+		}
+	}
+
+	// Implied technologies are added without version, unless they were detected on their own
+	for _, signature := range signatures {
+		if _, found := detected[signature.Name]; !found {
+			continue
+		}
+
+		for _, implied := range signature.Implies {
+			if _, found := detected[implied]; !found {
+				detected[implied] = Technology{
+					Name:     implied,
+					Category: categoryOf(implied),
+					Evidence: "implied by " + signature.Name,
+				}
+			}
+		}
+	}
+
+	var result []Technology
+	for _, signature := range signatures {
+		if technology, found := detected[signature.Name]; found {
+			result = append(result, technology)
+		}
+	}
+
+	return result
+}
+
+func (signature compiledSignature) detect(header http.Header, cookies map[string]string, meta map[string][]string, scripts []string, body []byte) (Technology, bool) {
+	technology := Technology{
+		Name:     signature.Name,
+		Category: signature.Category,
+	}
+
+	found := false
+
+	// Every evidence is checked, since the version may only be visible on one of them
+	evidence := func(expression *regexp.Regexp, value string, source string) {
+		match := expression.FindStringSubmatch(value)
+
+		if match == nil {
+			return
+		}
+
+		if !found {
+			technology.Evidence = source
+		}
+
+		found = true
+
+		if technology.Version == "" && len(match) > 1 && match[1] != "" {
+			technology.Version = match[1]
+			technology.Evidence = source
+		}
+	}
+
+	for _, name := range sortedKeys(signature.headers) {
+		for _, value := range header.Values(name) {
+			evidence(signature.headers[name], value, "header "+http.CanonicalHeaderKey(name))
+		}
+	}
+
+	for _, name := range sortedKeys(signature.cookies) {
+		if value, exists := cookies[strings.ToLower(name)]; exists {
+			evidence(signature.cookies[name], value, "cookie "+name)
+		}
+	}
+
+	for _, name := range sortedKeys(signature.meta) {
+		for _, value := range meta[strings.ToLower(name)] {
+			evidence(signature.meta[name], value, fmt.Sprintf("meta %s", name))
+		}
+	}
+
+	for _, expression := range signature.scripts {
+		for _, script := range scripts {
+			evidence(expression, script, "script src")
+		}
+	}
+
+	if len(signature.html) != 0 && len(body) != 0 {
+		content := string(body)
+
+		for _, expression := range signature.html {
+			evidence(expression, content, "html")
+		}
+	}
+
+	return technology, found
+}
+
+// Cookie names are matched case insensitively, values are kept as is
+func parseCookies(header http.Header) map[string]string {
+	cookies := make(map[string]string)
+
+	response := http.Response{Header: header}
+	for _, cookie := range response.Cookies() {
+		cookies[strings.ToLower(cookie.Name)] = cookie.Value
+	}
+
+	return cookies
+}
+
+// Collects meta tag contents by lowercase name, and the sources of every script
+func parseDocument(document *html.Node) (map[string][]string, []string) {
+	meta := make(map[string][]string)
+	var scripts []string
+
+	if document == nil {
+		return meta, scripts
+	}
+
+	for node := range document.Descendants() {
+		if node.Type != html.ElementNode {
+			continue
+		}
+
+		attributes := make(map[string]string)
+		for _, attr := range node.Attr {
+			attributes[attr.Key] = attr.Val
+		}
+
+		switch node.Data {
+		case "meta":
+			if name := attributes["name"]; name != "" {
+				meta[strings.ToLower(name)] = append(meta[strings.ToLower(name)], attributes["content"])
+			}
+		case "script":
+			if src := attributes["src"]; src != "" {
+				scripts = append(scripts, src)
+			}
+		}
+	}
+
+	return meta, scripts
+}
+
+func categoryOf(name string) string {
+	for _, signature := range signatures {
+		if signature.Name == name {
+			return signature.Category
+		}
+	}
+
+	return ""
+}
+
+// Map iteration order is random, sorting keeps the evidence of a detection stable between runs
+func sortedKeys(expressions map[string]*regexp.Regexp) []string {
+	var keys []string
+	for key := range expressions {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
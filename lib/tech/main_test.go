@@ -0,0 +1,93 @@
+package tech
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestDetect(t *testing.T) {
+	body := []byte(`<html>
+		<head>
+			<meta name="generator" content="WordPress 5.8.2">
+			<link rel="stylesheet" href="/wp-content/themes/shop/style.css">
+			<script src="/wp-includes/js/jquery/jquery-1.12.4.min.js"></script>
+		</head>
+		<body></body>
+		</html>`)
+
+	document, _ := html.Parse(bytes.NewReader(body))
+
+	header := http.Header{}
+	header.Set("Server", "nginx/1.18.0")
+	header.Add("Set-Cookie", "PHPSESSID=abc; Path=/")
+
+	detected := Detect(header, body, document)
+
+	expected := []Technology{
+		{Name: "WordPress", Category: "cms", Version: "5.8.2", Evidence: "meta generator"},
+		{Name: "Nginx", Category: "server", Version: "1.18.0", Evidence: "header Server"},
+		{Name: "PHP", Category: "language", Evidence: "cookie PHPSESSID"},
+		{Name: "jQuery", Category: "javascript", Version: "1.12.4", Evidence: "script src"},
+	}
+
+	if len(expected) != len(detected) {
+		t.Fatalf("Detect; want %+v; got %+v", expected, detected)
+	}
+
+	for i := range expected {
+		if expected[i] != detected[i] {
+			t.Errorf("Detect; want %+v; got %+v", expected[i], detected[i])
+		}
+	}
+
+	t.Run("implied technology", func(t *testing.T) {
+		body := []byte(`<script id="__NEXT_DATA__" type="application/json">{}</script>`)
+		detected := Detect(http.Header{}, body, nil)
+
+		if len(detected) != 2 || detected[0].Name != "Next.js" || detected[1].Evidence != "implied by Next.js" {
+			t.Errorf("Detect; want Next.js implying React; got %+v", detected)
+		}
+	})
+}
+
+func TestMatchesConstraint(t *testing.T) {
+	assert := func(t testing.TB, version string, constraint string, expected bool) {
+		t.Helper()
+		if actual := MatchesConstraint(version, constraint); expected != actual {
+			t.Errorf("MatchesConstraint(%q, %q); want %v; got %v", version, constraint, expected, actual)
+		}
+	}
+
+	t.Run("comparisons", func(t *testing.T) {
+		assert(t, "5.8.2", "< 6", true)
+		assert(t, "6.0", "< 6", false)
+		assert(t, "6", ">= 6.0.0", true)
+		assert(t, "1.10", "> 1.9", true)
+		assert(t, "2.4.49", "= 2.4.49", true)
+		assert(t, "2.4.50", "!= 2.4.49", true)
+	})
+
+	t.Run("ranges", func(t *testing.T) {
+		assert(t, "1.5", ">= 1.0, < 2", true)
+		assert(t, "2.0", ">= 1.0, < 2", false)
+	})
+
+	t.Run("prefixes", func(t *testing.T) {
+		assert(t, "1.12.4", "1.x", true)
+		assert(t, "1.12.4", "1.*.4", true)
+		assert(t, "3.6.0", "1.x", false)
+		assert(t, "1.2", "1.2.3", false)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		assert(t, "", "< 6", false)
+		assert(t, "", "", true)
+	})
+
+	t.Run("suffixes", func(t *testing.T) {
+		assert(t, "5.0.0-beta", "< 5.1", true)
+	})
+}
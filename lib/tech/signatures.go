@@ -0,0 +1,92 @@
+package tech
+
+import (
+	_ "embed"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed signatures.yml
+var signaturesFile []byte
+
+// Signatures are embedded, so invalid ones are a programming error and fail on startup
+var signatures = mustLoadSignatures(signaturesFile)
+
+type Signature struct {
+	Name     string
+	Category string
+	Headers  map[string]string
+	Cookies  map[string]string
+	Meta     map[string]string
+	Scripts  []string
+	Html     []string
+
+	// Technologies that are always used together with this one (e.g. WordPress runs on PHP)
+	Implies []string
+}
+
+type compiledSignature struct {
+	Signature
+
+	headers map[string]*regexp.Regexp
+	cookies map[string]*regexp.Regexp
+	meta    map[string]*regexp.Regexp
+	scripts []*regexp.Regexp
+	html    []*regexp.Regexp
+}
+
+func mustLoadSignatures(data []byte) []compiledSignature {
+	var parsed []Signature
+
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		panic("unable to parse technology signatures. Reason: " + err.Error())
+	}
+
+	var compiled []compiledSignature
+	for _, signature := range parsed {
+		compiled = append(compiled, compiledSignature{
+			Signature: signature,
+			headers:   compileMap(signature.Headers),
+			cookies:   compileMap(signature.Cookies),
+			meta:      compileMap(signature.Meta),
+			scripts:   compileList(signature.Scripts),
+			html:      compileList(signature.Html),
+		})
+	}
+
+	return compiled
+}
+
+// Expressions are case insensitive, since header values and markup casing vary between deployments
+func compile(expression string) *regexp.Regexp {
+	return regexp.MustCompile("(?i)" + expression)
+}
+
+func compileMap(expressions map[string]string) map[string]*regexp.Regexp {
+	compiled := make(map[string]*regexp.Regexp)
+	for name, expression := range expressions {
+		compiled[name] = compile(expression)
+	}
+
+	return compiled
+}
+
+func compileList(expressions []string) []*regexp.Regexp {
+	var compiled []*regexp.Regexp
+	for _, expression := range expressions {
+		compiled = append(compiled, compile(expression))
+	}
+
+	return compiled
+}
+
+// Signatures of every detectable technology
+func Signatures() []Signature {
+	var list []Signature
+	for _, signature := range signatures {
+		list = append(list, signature.Signature)
+	}
+
+	return list
+}
@@ -0,0 +1,113 @@
+package tech
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Compares dotted versions segment by segment, missing segments count as 0 (1.2 == 1.2.0).
+// Returns -1, 0 or 1 when a is lower, equal or greater than b
+func CompareVersions(a string, b string) int {
+	left := versionSegments(a)
+	right := versionSegments(b)
+
+	for i := 0; i < max(len(left), len(right)); i++ {
+		var l, r int
+		if i < len(left) {
+			l = left[i]
+		}
+		if i < len(right) {
+			r = right[i]
+		}
+
+		switch {
+		case l < r:
+			return -1
+		case l > r:
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// Checks a version against a comma separated list of conditions, every condition must be satisfied.
+// Conditions are a comparison (<, <=, >, >=, =, !=) followed by a version, or a version prefix where
+// x or * matches any segment (1.x matches 1.12.4). Unknown versions never satisfy a constraint
+func MatchesConstraint(version string, constraint string) bool {
+	if strings.TrimSpace(constraint) == "" {
+		return true
+	}
+
+	if version == "" {
+		return false
+	}
+
+	for _, condition := range strings.Split(constraint, ",") {
+		if !matchesCondition(version, strings.TrimSpace(condition)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchesCondition(version string, condition string) bool {
+	for _, operator := range []string{"<=", ">=", "!=", "<", ">", "="} {
+		if !strings.HasPrefix(condition, operator) {
+			continue
+		}
+
+		comparison := CompareVersions(version, strings.TrimSpace(strings.TrimPrefix(condition, operator)))
+
+		switch operator {
+		case "<=":
+			return comparison <= 0
+		case ">=":
+			return comparison >= 0
+		case "!=":
+			return comparison != 0
+		case "<":
+			return comparison < 0
+		case ">":
+			return comparison > 0
+		default:
+			return comparison == 0
+		}
+	}
+
+	return matchesPrefix(version, condition)
+}
+
+func matchesPrefix(version string, prefix string) bool {
+	segments := strings.Split(version, ".")
+
+	for i, expected := range strings.Split(prefix, ".") {
+		if expected == "x" || expected == "*" {
+			continue
+		}
+
+		if i >= len(segments) || segments[i] != expected {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Non numeric suffixes are ignored (1.2.3-beta is 1.2.3)
+func versionSegments(version string) []int {
+	var segments []int
+
+	for _, part := range strings.Split(strings.TrimSpace(version), ".") {
+		end := 0
+		for end < len(part) && part[end] >= '0' && part[end] <= '9' {
+			end++
+		}
+
+		number, _ := strconv.Atoi(part[:end])
+		segments = append(segments, number)
+	}
+
+	return segments
+}
@@ -1,13 +1,68 @@
 package utils
 
-import "strings"
+import (
+	"strings"
+	"unicode/utf8"
+)
 
 func ContainsAny(content string, words []string) bool {
+	_, found := FindAny(content, words)
+
+	return found
+}
+
+// Same as ContainsAny, ignoring case on both the content and the words
+func ContainsAnyFold(content string, words []string) bool {
+	content = strings.ToLower(content)
+
 	for _, word := range words {
-		if strings.Contains(content, word) {
+		if strings.Contains(content, strings.ToLower(word)) {
 			return true
 		}
 	}
 
 	return false
 }
+
+// Returns the first word from the list found in the content
+func FindAny(content string, words []string) (string, bool) {
+	for _, word := range words {
+		if strings.Contains(content, word) {
+			return word, true
+		}
+	}
+
+	return "", false
+}
+
+// Shortens content to at most `size` characters, keeping the area around the first occurrence of a word visible
+func Excerpt(content string, word string, size int) string {
+	content = strings.Join(strings.Fields(content), " ")
+
+	// Offsets are counted in runes, so multi-byte characters are never split
+	runes := []rune(content)
+
+	if len(runes) <= size {
+		return content
+	}
+
+	start := 0
+	if index := strings.Index(content, word); index != -1 {
+		start = max(0, utf8.RuneCountInString(content[:index])-(size-utf8.RuneCountInString(word))/2)
+	}
+
+	end := min(len(runes), start+size)
+	start = max(0, end-size)
+
+	excerpt := string(runes[start:end])
+
+	if start > 0 {
+		excerpt = "..." + excerpt
+	}
+
+	if end < len(runes) {
+		excerpt = excerpt + "..."
+	}
+
+	return excerpt
+}
@@ -0,0 +1,228 @@
+package wordlist
+
+import (
+	"bufio"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	minWordLength = 3
+	maxWordLength = 32
+)
+
+var (
+	scriptIdentifier  = regexp.MustCompile(`[A-Za-z_$][A-Za-z0-9_$]*`)
+	camelCaseBoundary = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+)
+
+// Attributes that usually carry names chosen by the developers of the target
+var namingAttributes = []string{"id", "class", "name", "for", "data-testid"}
+
+// Collector gathers words from every evaluated target, to build a word list that is specific to the target,
+// for later subdomain and path brute-forcing. Words are ranked by the number of documents they show up on,
+// so words that are part of the product vocabulary rank higher than words from a single page
+type Collector struct {
+	mutex       sync.Mutex
+	occurrences map[string]int
+	documents   map[string]int
+}
+
+type Word struct {
+	Word        string
+	Documents   int
+	Occurrences int
+}
+
+func NewCollector() *Collector {
+	return &Collector{
+		occurrences: make(map[string]int),
+		documents:   make(map[string]int),
+	}
+}
+
+// Collects words from path segments and parameter names of a URL, and from the text, attributes and scripts of its document
+func (collector *Collector) Collect(targetUrl string, document *html.Node) {
+	var tokens []string
+
+	if parsed, err := url.Parse(targetUrl); err == nil {
+		tokens = append(tokens, strings.Split(parsed.Path, "/")...)
+
+		for key := range parsed.Query() {
+			tokens = append(tokens, key)
+		}
+	}
+
+	if document != nil {
+		tokens = append(tokens, documentTokens(document)...)
+	}
+
+	collector.add(tokens)
+}
+
+func (collector *Collector) add(tokens []string) {
+	seen := make(map[string]bool)
+
+	for _, token := range tokens {
+		for _, word := range splitToken(token) {
+			if !isRelevant(word) {
+				continue
+			}
+
+			collector.mutex.Lock()
+			collector.occurrences[word]++
+
+			if !seen[word] {
+				collector.documents[word]++
+				seen[word] = true
+			}
+
+			collector.mutex.Unlock()
+		}
+	}
+}
+
+// Lists collected words, the ones found on most documents first
+func (collector *Collector) Words() []Word {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	var words []Word
+	for word, occurrences := range collector.occurrences {
+		words = append(words, Word{
+			Word:        word,
+			Documents:   collector.documents[word],
+			Occurrences: occurrences,
+		})
+	}
+
+	sort.Slice(words, func(i, j int) bool {
+		if words[i].Documents != words[j].Documents {
+			return words[i].Documents > words[j].Documents
+		}
+
+		if words[i].Occurrences != words[j].Occurrences {
+			return words[i].Occurrences > words[j].Occurrences
+		}
+
+		return words[i].Word < words[j].Word
+	})
+
+	return words
+}
+
+func (collector *Collector) WriteFile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+
+	for _, word := range collector.Words() {
+		writer.WriteString(word.Word)
+		writer.WriteString("\n")
+	}
+
+	return writer.Flush()
+}
+
+func documentTokens(document *html.Node) []string {
+	var tokens []string
+
+	for node := range document.Descendants() {
+		switch node.Type {
+		case html.TextNode:
+			if node.Parent != nil && node.Parent.Type == html.ElementNode && node.Parent.Data == "style" {
+				continue
+			}
+
+			// Scripts are reduced to their identifiers, so that strings of operators don't produce noise
+			if node.Parent != nil && node.Parent.Type == html.ElementNode && node.Parent.Data == "script" {
+				tokens = append(tokens, scriptIdentifier.FindAllString(node.Data, -1)...)
+			} else {
+				tokens = append(tokens, strings.Fields(node.Data)...)
+			}
+
+		case html.ElementNode:
+			for _, attr := range node.Attr {
+				for _, name := range namingAttributes {
+					if attr.Key == name {
+						tokens = append(tokens, strings.Fields(attr.Val)...)
+					}
+				}
+			}
+		}
+	}
+
+	return tokens
+}
+
+// Splits compound identifiers (camelCase, snake_case, kebab-case) into normalized words
+func splitToken(token string) []string {
+	token = camelCaseBoundary.ReplaceAllString(token, "$1 $2")
+
+	fields := strings.FieldsFunc(token, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var words []string
+	for _, field := range fields {
+		words = append(words, normalize(field))
+	}
+
+	return words
+}
+
+// Folds case and removes diacritics of Latin letters, so "Café" and "cafe" are the same word. Marks of other
+// scripts are kept, since they are part of letters like "й"
+func normalize(word string) string {
+	var builder strings.Builder
+	latin := false
+
+	for _, r := range norm.NFD.String(word) {
+		if !unicode.Is(unicode.Mn, r) {
+			latin = unicode.Is(unicode.Latin, r)
+		} else if latin {
+			continue
+		}
+
+		builder.WriteRune(r)
+	}
+
+	return cases.Fold().String(norm.NFC.String(builder.String()))
+}
+
+func isRelevant(word string) bool {
+	length := utf8.RuneCountInString(word)
+
+	if length < minWordLength || length > maxWordLength {
+		return false
+	}
+
+	if stopwords[word] {
+		return false
+	}
+
+	// Words need letters, numbers and hashes are not useful for brute-forcing
+	letters := 0
+	for _, r := range word {
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+
+	return letters*2 > length
+}
@@ -0,0 +1,64 @@
+package wordlist
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestCollector(t *testing.T) {
+	login := `<html>
+		<body>
+			<form id="loginForm" class="auth-panel">
+				<input type="email" name="user_email">
+				<input type="password" name="password">
+			</form>
+			<p>Welcome to the Café Rewards portal</p>
+			<p lang="ru">Платежный кабинет, личный счёт</p>
+			<script>
+				const rewardsClient = new RewardsApi(window.location)
+				function refreshBalance() { return rewardsClient.get(12345) }
+			</script>
+		</body>
+		</html>`
+
+	search := `<html><body><p>Search the rewards catalog</p></body></html>`
+
+	collector := NewCollector()
+	collector.Collect("http://localhost/account/login?returnUrl=/", getHTMLDocument(login))
+	collector.Collect("http://localhost/rewards/search", getHTMLDocument(search))
+
+	words := make(map[string]Word)
+	for _, word := range collector.Words() {
+		words[word.Word] = word
+	}
+
+	t.Run("collects relevant words", func(t *testing.T) {
+		for _, expected := range []string{"account", "login", "url", "form", "auth", "panel", "user", "email", "password", "welcome", "cafe", "portal", "rewards", "client", "refresh", "balance", "search", "catalog", "платежный", "кабинет", "личный", "счёт"} {
+			if _, found := words[expected]; !found {
+				t.Errorf("Collect; want %q on word list; got %v", expected, collector.Words())
+			}
+		}
+	})
+
+	t.Run("filters stopwords and noise", func(t *testing.T) {
+		for _, unexpected := range []string{"the", "to", "const", "function", "return", "12345", "new", "window"} {
+			if _, found := words[unexpected]; found {
+				t.Errorf("Collect; want %q filtered out", unexpected)
+			}
+		}
+	})
+
+	t.Run("ranks words found on more documents first", func(t *testing.T) {
+		if first := collector.Words()[0]; first.Word != "rewards" || first.Documents != 2 {
+			t.Errorf("Words; want rewards ranked first; got %+v", first)
+		}
+	})
+}
+
+func getHTMLDocument(content string) *html.Node {
+	document, _ := html.Parse(strings.NewReader(content))
+
+	return document
+}
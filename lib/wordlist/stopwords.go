@@ -0,0 +1,36 @@
+package wordlist
+
+import "strings"
+
+// There is no part of speech tagger available, so function words are grouped by their part of speech instead.
+// These classes never name a product or a feature, and are dropped from the generated word list
+var partsOfSpeech = map[string]string{
+	"determiner":   "the a an this that these those each every either neither some any no all both half several many much more most few less least other another such what which whose",
+	"pronoun":      "i me my mine myself you your yours yourself yourselves he him his himself she her hers herself it its itself we us our ours ourselves they them their theirs themselves who whom whoever whatever anyone anything anybody someone something somebody everyone everything everybody nobody nothing none one ones",
+	"preposition":  "about above across after against along amid among around as at before behind below beneath beside besides between beyond by despite down during except for from in inside into like near of off on onto out outside over past per since than through throughout till to toward towards under underneath unlike until up upon via with within without",
+	"conjunction":  "and but or nor so yet because although though while whereas if unless whether once whenever wherever",
+	"auxiliary":    "am is are was were be been being have has had having do does did doing done can could may might must shall should will would ought",
+	"adverb":       "not very too also just only even still already always never often sometimes usually really quite rather almost perhaps maybe here there where when why how then now again ever soon yes",
+	"interjection": "oh ah hey hello hi please thanks thank ok okay",
+}
+
+// Reserved words of JavaScript, which show up in every inline script and say nothing about the target
+var scriptKeywords = "break case catch class const continue debugger default delete else export extends false finally function import instanceof let new null return super switch throw true try typeof undefined var void async await static get set of document window console length prototype constructor"
+
+var stopwords = buildStopwords()
+
+func buildStopwords() map[string]bool {
+	result := make(map[string]bool)
+
+	for _, words := range partsOfSpeech {
+		for _, word := range strings.Fields(words) {
+			result[word] = true
+		}
+	}
+
+	for _, word := range strings.Fields(scriptKeywords) {
+		result[word] = true
+	}
+
+	return result
+}